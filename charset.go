@@ -24,6 +24,15 @@ type Glyph struct {
 }
 
 func (g *Glyph) GetTexturePositions(font FontLike) (tP1, tP2 Point) {
+	return g.GetTexturePositionsInset(font, 0)
+}
+
+// GetTexturePositionsInset returns the texture coordinates of the glyph's quad
+// moved inwards by inset texels on every side.  An inset of half a texel keeps
+// linear filtering from sampling the neighbouring texels; it should be used
+// with sprite sheets baked with BakeOptions.Padding, whose glyphs are surrounded
+// by transparent texels so that nothing of the glyph is lost.
+func (g *Glyph) GetTexturePositionsInset(font FontLike, inset float32) (tP1, tP2 Point) {
 	// Quad width/height
 
 	// Originally the ttf width value was being used.  This, however, differs from the Advance value.
@@ -33,8 +42,8 @@ func (g *Glyph) GetTexturePositions(font FontLike) (tP1, tP2 Point) {
 
 	vh := float32(g.Height)
 
-	// Unfortunately with the current font, if I don't add a small offset to the Y axis location
-	// the bottom edge of the character above might appear.
+	// Without padding, the bottom edge of the character above might appear when the texture is
+	// filtered linearly.
 	//
 	// EG:
 	// Wrapping 16 characters per line:
@@ -43,17 +52,16 @@ func (g *Glyph) GetTexturePositions(font FontLike) (tP1, tP2 Point) {
 	// runeRange := gltext.RuneRange{Low: 1, High: 128}
 	// runeRanges = append(runeRanges, runeRange)
 	//
-	// The resulting image file will place "g" above "w".  The very bottom edge of "g" will show up
-	// when using the "w" character in a line of text. So the dirty hack is to remove just a bit of
-	// the original top as per below.  This is not ideal.  Either I am not understanding something
-	// about the glyph layout or this will have to be tweaked based on the font being used.
-	// See the file example_image.png.
+	// The resulting image file will place "g" above "w".  The very bottom edge of "g" used to show up
+	// when using the "w" character in a line of text, and the dirty hack was to remove just a bit of
+	// the original top through the adjustHeight parameter.  Baking with BakeOptions.Padding separates
+	// the glyphs instead, see the file example_image.png.
 
 	// texture point 1
-	tP1 = Point{X: float32(g.X) / font.GetTextureWidth(), Y: float32(g.Y) / font.GetTextureHeight()}
+	tP1 = Point{X: (float32(g.X) + inset) / font.GetTextureWidth(), Y: (float32(g.Y) + inset) / font.GetTextureHeight()}
 
 	// texture point 2
-	tP2 = Point{X: (float32(g.X) + vw) / font.GetTextureWidth(), Y: (float32(g.Y) + vh) / font.GetTextureHeight()}
+	tP2 = Point{X: (float32(g.X) + vw - inset) / font.GetTextureWidth(), Y: (float32(g.Y) + vh - inset) / font.GetTextureHeight()}
 
 	return
 }
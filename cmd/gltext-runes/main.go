@@ -0,0 +1,125 @@
+// Command gltext-runes prints the rune ranges used by a set of UTF-8 text
+// files, such as translation catalogs, so that only the required glyphs are
+// baked into a font.
+//
+// Usage:
+//
+//	gltext-runes [-gap n] [-json] [-strings] [-font file.ttf [-face n]] file...
+//
+// Without files the text is read from standard input.  The ranges are written
+// in the form accepted by gltext.ParseRuneRanges unless -json is given.  When
+// a font is supplied, the coverage of each range by that font is reported on
+// standard error.  -face selects the face of a font collection.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/4ydx/gltext"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+func main() {
+	gap := flag.Int("gap", 0, "merge ranges separated by at most this many unused runes")
+	asJSON := flag.Bool("json", false, "print the ranges as a JSON array of {Low, High} objects")
+	stringsOnly := flag.Bool("strings", false, "treat the input as JSON and only scan string values")
+	fontPath := flag.String("font", "", "report which of the runes are present in this font")
+	faceIndex := flag.Int("face", 0, "face of the font collection given by -font")
+	flag.Parse()
+
+	rs := make(gltext.RuneSet)
+	if flag.NArg() == 0 {
+		if err := scan(rs, os.Stdin, *stringsOnly); err != nil {
+			fail(err)
+		}
+	}
+	for _, path := range flag.Args() {
+		fd, err := os.Open(path)
+		if err != nil {
+			fail(err)
+		}
+		err = scan(rs, fd, *stringsOnly)
+		fd.Close()
+		if err != nil {
+			fail(fmt.Errorf("%s: %v", path, err))
+		}
+	}
+
+	runeRanges := rs.RuneRanges().MergeGaps(*gap)
+	if *fontPath != "" {
+		if err := report(*fontPath, *faceIndex, runeRanges); err != nil {
+			fail(err)
+		}
+	}
+	if *asJSON {
+		data, err := json.Marshal(runeRanges)
+		if err != nil {
+			fail(err)
+		}
+		fmt.Println(string(data))
+		return
+	}
+	fmt.Println(runeRanges)
+	fmt.Fprintf(os.Stderr, "%d runes in %d ranges\n", runeRanges.RuneCount(), len(runeRanges))
+}
+
+// scan adds the runes of r to rs.  When stringsOnly is set r must hold a JSON
+// document and only its string values are scanned, leaving out keys and syntax.
+func scan(rs gltext.RuneSet, r io.Reader, stringsOnly bool) error {
+	if !stringsOnly {
+		_, err := rs.ReadFrom(r)
+		return err
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	var doc interface{}
+	if err = json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	addStrings(rs, doc)
+	return nil
+}
+
+// report prints the coverage of runeRanges by the font face found at path.
+func report(path string, faceIndex int, runeRanges gltext.RuneRanges) error {
+	fd, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	coverage, err := gltext.FontFaceCoverage(fd, faceIndex, runeRanges)
+	if err != nil {
+		return err
+	}
+	for _, rc := range coverage.Ranges {
+		fmt.Fprintf(os.Stderr, "%-20s %6.2f%% (%d missing)\n", gltext.RuneRanges{rc.Range}, rc.Percent(), rc.Missing)
+	}
+	fmt.Fprintf(os.Stderr, "%.2f%% coverage, missing: %v\n", coverage.Percent(), coverage.Missing)
+	return nil
+}
+
+func addStrings(rs gltext.RuneSet, v interface{}) {
+	switch v := v.(type) {
+	case string:
+		rs.Add(v)
+	case []interface{}:
+		for _, e := range v {
+			addStrings(rs, e)
+		}
+	case map[string]interface{}:
+		for _, e := range v {
+			addStrings(rs, e)
+		}
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
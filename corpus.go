@@ -0,0 +1,149 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gltext
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// RuneSet collects the runes used by a body of text so that a font only needs
+// to hold the glyphs that will actually be drawn.  Control characters such as
+// newlines and tabs are never drawn and are therefore ignored, as are invalid
+// UTF-8 sequences.
+type RuneSet map[rune]struct{}
+
+// Add records every drawable rune of s.
+func (rs RuneSet) Add(s string) {
+	for i, r := range s {
+		if r == utf8.RuneError {
+			if _, size := utf8.DecodeRuneInString(s[i:]); size == 1 {
+				continue
+			}
+		}
+		rs.addRune(r)
+	}
+}
+
+func (rs RuneSet) addRune(r rune) {
+	if !unicode.IsControl(r) {
+		rs[r] = struct{}{}
+	}
+}
+
+// ReadFrom records every drawable rune read from r, which must contain UTF-8 text.
+func (rs RuneSet) ReadFrom(r io.Reader) (n int64, err error) {
+	br := bufio.NewReader(r)
+	for {
+		char, size, err := br.ReadRune()
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+		n += int64(size)
+		if char == utf8.RuneError && size == 1 {
+			continue
+		}
+		rs.addRune(char)
+	}
+}
+
+// RuneRanges returns the minimal normalized ranges covering the set.
+func (rs RuneSet) RuneRanges() RuneRanges {
+	runes := make([]rune, 0, len(rs))
+	for r := range rs {
+		runes = append(runes, r)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+
+	rr := make(RuneRanges, 0)
+	for _, r := range runes {
+		last := len(rr) - 1
+		if last >= 0 && rr[last].High+1 == r {
+			rr[last].High = r
+			continue
+		}
+		rr = append(rr, RuneRange{Low: r, High: r})
+	}
+	return rr
+}
+
+// RuneRangesFromStrings returns the minimal ranges covering every drawable rune of strs.
+func RuneRangesFromStrings(strs ...string) RuneRanges {
+	rs := make(RuneSet)
+	for _, s := range strs {
+		rs.Add(s)
+	}
+	return rs.RuneRanges()
+}
+
+// RuneRangesFromReader returns the minimal ranges covering every drawable rune read from r.
+func RuneRangesFromReader(r io.Reader) (RuneRanges, error) {
+	rs := make(RuneSet)
+	if _, err := rs.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	return rs.RuneRanges(), nil
+}
+
+// RuneRangesFromFiles returns the minimal ranges covering every drawable rune
+// found in the given UTF-8 text files.
+func RuneRangesFromFiles(paths ...string) (RuneRanges, error) {
+	rs := make(RuneSet)
+	for _, path := range paths {
+		fd, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		_, err = rs.ReadFrom(fd)
+		fd.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rs.RuneRanges(), nil
+}
+
+// MergeGaps returns normalized ranges in which neighbouring ranges separated by
+// at most maxGap missing runes are joined.  Fewer ranges cost a few unused
+// glyphs in the sprite sheet but make glyph lookups cheaper.
+func (rr RuneRanges) MergeGaps(maxGap int) RuneRanges {
+	merged := rr.Normalize()
+	if maxGap <= 0 || len(merged) == 0 {
+		return merged
+	}
+	at := 0
+	for _, r := range merged[1:] {
+		if int(r.Low-merged[at].High-1) <= maxGap {
+			merged[at].High = r.High
+			continue
+		}
+		at++
+		merged[at] = r
+	}
+	return merged[:at+1]
+}
+
+// String describes the ranges using code points, e.g. "U+0020-U+007E + U+3000".
+// The result can be read back with ParseRuneRanges.
+func (rr RuneRanges) String() string {
+	terms := make([]string, len(rr))
+	for i, r := range rr {
+		if r.Low == r.High {
+			terms[i] = fmt.Sprintf("%U", r.Low)
+		} else {
+			terms[i] = fmt.Sprintf("%U-%U", r.Low, r.High)
+		}
+	}
+	return strings.Join(terms, " + ")
+}
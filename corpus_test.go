@@ -0,0 +1,39 @@
+package gltext
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRuneRangesFromText(t *testing.T) {
+	expected := RuneRanges{{Low: 'a', High: 'c'}, {Low: 'x', High: 'x'}, {Low: 'あ', High: 'あ'}}
+	if rr := RuneRangesFromStrings("cab\n", "x\tあ", "\xff"); !reflect.DeepEqual(rr, expected) {
+		t.Error("Unexpected ranges", rr)
+	}
+	rr, err := RuneRangesFromReader(strings.NewReader("cab\nxあ\xff"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rr, expected) {
+		t.Error("Unexpected ranges", rr)
+	}
+
+	parsed, err := ParseRuneRanges(rr.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, rr) {
+		t.Error("String should round trip", rr.String())
+	}
+}
+
+func TestMergeGaps(t *testing.T) {
+	rr := RuneRanges{{Low: 10, High: 12}, {Low: 15, High: 15}, {Low: 30, High: 31}}
+	if m := rr.MergeGaps(2); !reflect.DeepEqual(m, RuneRanges{{Low: 10, High: 15}, {Low: 30, High: 31}}) {
+		t.Error("Unexpected merge", m)
+	}
+	if m := rr.MergeGaps(0); !reflect.DeepEqual(m, rr) {
+		t.Error("No gap should leave the ranges alone", m)
+	}
+}
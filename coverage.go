@@ -0,0 +1,100 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gltext
+
+import (
+	"golang.org/x/image/font"
+	"io"
+	"io/ioutil"
+)
+
+// RangeCoverage reports how many runes of a single range a font provides.
+type RangeCoverage struct {
+	Range   RuneRange
+	Present int // runes that have a glyph of their own
+	Missing int // runes that map to glyph 0, the .notdef box
+}
+
+// Percent returns the percentage of the range that the font provides.
+func (rc RangeCoverage) Percent() float64 {
+	total := rc.Present + rc.Missing
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(rc.Present) / float64(total)
+}
+
+// Coverage reports which runes of a set of RuneRanges are found in a font's
+// character map.
+type Coverage struct {
+	// Ranges holds one entry per normalized input range.
+	Ranges []RangeCoverage
+
+	// Present and Missing split the input into the runes the font has and
+	// the runes that would be drawn as .notdef.
+	Present RuneRanges
+	Missing RuneRanges
+}
+
+// Percent returns the percentage of all requested runes that the font provides.
+func (c *Coverage) Percent() float64 {
+	present, missing := c.Present.RuneCount(), c.Missing.RuneCount()
+	if present+missing == 0 {
+		return 0
+	}
+	return 100 * float64(present) / float64(present+missing)
+}
+
+// FontCoverage reads a font from the given stream and reports which runes of
+// runeRanges it contains.  The first face of a font collection is examined.
+func FontCoverage(r io.Reader, runeRanges RuneRanges) (*Coverage, error) {
+	return FontFaceCoverage(r, 0, runeRanges)
+}
+
+// FontFaceCoverage behaves like FontCoverage for the given face of a font
+// collection.
+func FontFaceCoverage(r io.Reader, faceIndex int, runeRanges RuneRanges) (*Coverage, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	face, err := parseFace(data, faceIndex, 0, font.HintingNone)
+	if err != nil {
+		return nil, err
+	}
+	return fontCoverage(face, runeRanges), nil
+}
+
+func fontCoverage(face bakeFace, runeRanges RuneRanges) *Coverage {
+	c := &Coverage{
+		Ranges:  make([]RangeCoverage, 0),
+		Present: make(RuneRanges, 0),
+		Missing: make(RuneRanges, 0),
+	}
+	for _, runeRange := range runeRanges.Normalize() {
+		rc := RangeCoverage{Range: runeRange}
+		for ch := runeRange.Low; ch <= runeRange.High; ch++ {
+			if face.index(ch) == 0 {
+				rc.Missing++
+				c.Missing = appendRune(c.Missing, ch)
+			} else {
+				rc.Present++
+				c.Present = appendRune(c.Present, ch)
+			}
+		}
+		c.Ranges = append(c.Ranges, rc)
+	}
+	return c
+}
+
+// appendRune extends the final range when ch directly follows it.
+// Runes must be appended in increasing order.
+func appendRune(rr RuneRanges, ch rune) RuneRanges {
+	if last := len(rr) - 1; last >= 0 && rr[last].High+1 == ch {
+		rr[last].High = ch
+		return rr
+	}
+	return append(rr, RuneRange{Low: ch, High: ch})
+}
@@ -30,31 +30,30 @@ func TextDebug(message string) {
 // - Open the texture in an image editor and, using the upper left hand corner as (0,0)
 //   move to the location (x,y).  This is where opengl will pinpoint your rune within the image.
 func PrintVBO(vbo []float32, w, h float32) {
-	if len(vbo)%4 != 0 {
-		fmt.Println("VBO appears to have an incorrect size.  Should be a multiple of 4.")
+	PrintVertices(vbo, 4, w, h)
+}
+
+// PrintVertices is PrintVBO for vertices of stride floats that begin with the position
+// and texture location.  Any further values, such as colors, are printed after them.
+func PrintVertices(vbo []float32, stride int, w, h float32) {
+	if stride < 4 || len(vbo)%(4*stride) != 0 {
+		fmt.Printf("VBO appears to have an incorrect size.  Should be a multiple of %d.\n", 4*stride)
+		return
 	}
-	// drawing a quad take 16 floats: (2 x,y + 2 u,v) * 4 indices
-	for i := 0; i < len(vbo); i += 16 {
+	// drawing a quad takes 4 vertices of (2 x,y + 2 u,v + extra) floats
+	corners := []string{"(0,0)", "(1,0)", "(1,1)", "(0,1)"}
+	for i := 0; i < len(vbo); i += 4 * stride {
 		fmt.Println("Quad")
-		at := i
-		fmt.Printf(
-			"(0,0) (%.2f,%.2f); (U,V) (%f,%f) -> (%f,%f)\n",
-			vbo[at], vbo[at+1], vbo[at+2], vbo[at+3], vbo[at+2]*w, vbo[at+3]*h,
-		)
-		at += 4
-		fmt.Printf(
-			"(1,0) (%.2f,%.2f); (U,V) (%f,%f) -> (%f,%f)\n",
-			vbo[at], vbo[at+1], vbo[at+2], vbo[at+3], vbo[at+2]*w, vbo[at+3]*h,
-		)
-		at += 4
-		fmt.Printf(
-			"(1,1) (%.2f,%.2f); (U,V) (%f,%f) -> (%f,%f)\n",
-			vbo[at], vbo[at+1], vbo[at+2], vbo[at+3], vbo[at+2]*w, vbo[at+3]*h,
-		)
-		at += 4
-		fmt.Printf(
-			"(0,1) (%.2f,%.2f); (U,V) (%f,%f) -> (%f,%f)\n",
-			vbo[at], vbo[at+1], vbo[at+2], vbo[at+3], vbo[at+2]*w, vbo[at+3]*h,
-		)
+		for c, corner := range corners {
+			at := i + c*stride
+			fmt.Printf(
+				"%s (%.2f,%.2f); (U,V) (%f,%f) -> (%f,%f)",
+				corner, vbo[at], vbo[at+1], vbo[at+2], vbo[at+3], vbo[at+2]*w, vbo[at+3]*h,
+			)
+			if stride > 4 {
+				fmt.Printf(" %v", vbo[at+4:at+stride])
+			}
+			fmt.Println()
+		}
 	}
 }
@@ -0,0 +1,68 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gltext
+
+import (
+	"math"
+)
+
+// Easing maps the linear progress of an animation, from 0 to 1, onto the
+// progress of the animated value.  Every easing starts at 0 and ends at 1.
+type Easing func(t float32) float32
+
+// Linear progresses at a constant rate.
+func Linear(t float32) float32 {
+	return t
+}
+
+// EaseInQuad starts slowly and accelerates.
+func EaseInQuad(t float32) float32 {
+	return t * t
+}
+
+// EaseOutQuad starts quickly and decelerates.
+func EaseOutQuad(t float32) float32 {
+	return t * (2 - t)
+}
+
+// EaseInOutQuad accelerates until halfway and decelerates afterwards.
+func EaseInOutQuad(t float32) float32 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return -1 + (4-2*t)*t
+}
+
+// EaseInCubic starts slower than EaseInQuad.
+func EaseInCubic(t float32) float32 {
+	return t * t * t
+}
+
+// EaseOutCubic ends slower than EaseOutQuad.
+func EaseOutCubic(t float32) float32 {
+	t--
+	return t*t*t + 1
+}
+
+// EaseInOutCubic is the cubic counterpart of EaseInOutQuad.
+func EaseInOutCubic(t float32) float32 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	t = 2*t - 2
+	return t*t*t/2 + 1
+}
+
+// EaseInOutSine follows a half cosine wave.
+func EaseInOutSine(t float32) float32 {
+	return float32(0.5 - math.Cos(math.Pi*float64(t))/2)
+}
+
+// EaseOutBack overshoots the target slightly before settling on it.
+func EaseOutBack(t float32) float32 {
+	const c = 1.70158
+	t--
+	return t*t*((c+1)*t+c) + 1
+}
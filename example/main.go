@@ -48,10 +48,13 @@ func main() {
 	// code from here
 	gltext.IsDebug = true
 
+	// mipmaps keep the text from shimmering when it is drawn below its baked size
+	fontOptions := v41.FontOptions{Mipmaps: v41.MipmapDownsample}
+
 	var font *v41.Font
 	config, err := gltext.LoadTruetypeFontConfig("fontconfigs", "font_1_honokamin")
 	if err == nil {
-		font, err = v41.NewFont(config)
+		font, err = v41.NewFontWithOptions(config, fontOptions)
 		if err != nil {
 			panic(err)
 		}
@@ -64,18 +67,19 @@ func main() {
 		defer fd.Close()
 
 		// Japanese character ranges
-		// http://www.rikai.com/library/kanjitables/kanji_codes.unicode.shtml
-		runeRanges := make(gltext.RuneRanges, 0)
-		runeRanges = append(runeRanges, gltext.RuneRange{Low: 32, High: 128})
-		runeRanges = append(runeRanges, gltext.RuneRange{Low: 0x3000, High: 0x3030})
-		runeRanges = append(runeRanges, gltext.RuneRange{Low: 0x3040, High: 0x309f})
-		runeRanges = append(runeRanges, gltext.RuneRange{Low: 0x30a0, High: 0x30ff})
-		runeRanges = append(runeRanges, gltext.RuneRange{Low: 0x4e00, High: 0x9faf})
-		runeRanges = append(runeRanges, gltext.RuneRange{Low: 0xff00, High: 0xffef})
+		runeRanges, err := gltext.ParseRuneRanges(
+			"U+0020-U+007F + CJK Symbols and Punctuation + Hiragana + Katakana + CJK Unified Ideographs + Halfwidth and Fullwidth Forms",
+		)
+		if err != nil {
+			panic(err)
+		}
 
 		scale := fixed.Int26_6(32)
 		runesPerRow := fixed.Int26_6(128)
-		config, err = gltext.NewTruetypeFontConfig(fd, scale, runeRanges, runesPerRow, 5)
+		// padding keeps neighbouring glyphs from bleeding into one another, also within
+		// the two mipmap levels a padding of 4 texels allows
+		opts := gltext.BakeOptions{Padding: 4}
+		config, err = gltext.NewTruetypeFontConfigWithOptions(fd, scale, runeRanges, runesPerRow, 0, opts)
 		if err != nil {
 			panic(err)
 		}
@@ -83,7 +87,7 @@ func main() {
 		if err != nil {
 			panic(err)
 		}
-		font, err = v41.NewFont(config)
+		font, err = v41.NewFontWithOptions(config, fontOptions)
 		if err != nil {
 			panic(err)
 		}
@@ -0,0 +1,230 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gltext
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"io"
+	"io/ioutil"
+)
+
+// bakeFace provides the glyphs of a single font face at a fixed size.
+// Distances are in 26.6 pixels with y increasing downwards, and outlines are
+// relative to the glyph's origin on the baseline.
+type bakeFace interface {
+	// index returns the glyph of the rune, 0 (.notdef) when the face lacks it.
+	index(ch rune) int
+	advance(index int) fixed.Int26_6
+	// bounds is the union of the bounds of every glyph in the face.
+	bounds() fixed.Rectangle26_6
+	outline(index int) (sfnt.Segments, error)
+}
+
+// parseFace reads a font file and returns the requested face at the given
+// size in 26.6 pixels per em.  TrueType fonts are read with freetype, which
+// supports hinting.  OpenType fonts with CFF outlines and font collections
+// (.ttc, .otc) are read with golang.org/x/image/font/sfnt; their outlines are
+// never hinted, hinting only rounds their metrics.
+func parseFace(data []byte, faceIndex int, ppem fixed.Int26_6, hinting font.Hinting) (bakeFace, error) {
+	if !bytes.HasPrefix(data, []byte("ttcf")) && !bytes.HasPrefix(data, []byte("OTTO")) {
+		ttf, err := truetype.Parse(data)
+		if err == nil {
+			if faceIndex != 0 {
+				return nil, fmt.Errorf("Face %d requested from a font file holding a single face.", faceIndex)
+			}
+			return &truetypeFace{ttf: ttf, ppem: ppem, hinting: hinting}, nil
+		}
+	}
+	c, err := sfnt.ParseCollection(data)
+	if err != nil {
+		return nil, err
+	}
+	if faceIndex < 0 || faceIndex >= c.NumFonts() {
+		return nil, fmt.Errorf("Face %d requested from a font file holding %d faces.", faceIndex, c.NumFonts())
+	}
+	f, err := c.Font(faceIndex)
+	if err != nil {
+		return nil, err
+	}
+	return &sfntFace{font: f, ppem: ppem, hinting: hinting}, nil
+}
+
+// FontFaces returns the full names of the faces held by a font file, in the
+// order expected by BakeOptions.FaceIndex.  TrueType and OpenType fonts hold a
+// single face, collections (.ttc, .otc) may hold several.
+func FontFaces(r io.Reader) ([]string, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	c, err := sfnt.ParseCollection(data)
+	if err != nil {
+		return nil, err
+	}
+	var buf sfnt.Buffer
+	names := make([]string, c.NumFonts())
+	for i := range names {
+		f, err := c.Font(i)
+		if err != nil {
+			return nil, err
+		}
+		// not every font names itself, an empty name still keeps its place
+		names[i], _ = f.Name(&buf, sfnt.NameIDFull)
+	}
+	return names, nil
+}
+
+type truetypeFace struct {
+	ttf     *truetype.Font
+	ppem    fixed.Int26_6
+	hinting font.Hinting
+	buf     truetype.GlyphBuf
+	xs      []fixed.Int26_6 // unhinted x coordinates for vertical hinting
+}
+
+func (f *truetypeFace) index(ch rune) int {
+	return int(f.ttf.Index(ch))
+}
+
+func (f *truetypeFace) advance(index int) fixed.Int26_6 {
+	if f.hinting != font.HintingFull {
+		return f.ttf.HMetric(f.ppem, truetype.Index(index)).AdvanceWidth
+	}
+	if err := f.load(index); err != nil {
+		return 0
+	}
+	return f.buf.AdvanceWidth
+}
+
+func (f *truetypeFace) bounds() fixed.Rectangle26_6 {
+	// truetype bounds have y increasing upwards
+	b := f.ttf.Bounds(f.ppem)
+	return fixed.Rectangle26_6{
+		Min: fixed.Point26_6{X: b.Min.X, Y: -b.Max.Y},
+		Max: fixed.Point26_6{X: b.Max.X, Y: -b.Min.Y},
+	}
+}
+
+// load fills the glyph buffer.  freetype only knows full hinting, vertical
+// hinting keeps the hinted y coordinates of the points with unhinted x ones.
+func (f *truetypeFace) load(index int) error {
+	if f.hinting != font.HintingVertical {
+		return f.buf.Load(f.ttf, f.ppem, truetype.Index(index), f.hinting)
+	}
+	if err := f.buf.Load(f.ttf, f.ppem, truetype.Index(index), font.HintingNone); err != nil {
+		return err
+	}
+	f.xs = f.xs[:0]
+	for _, p := range f.buf.Points {
+		f.xs = append(f.xs, p.X)
+	}
+	if err := f.buf.Load(f.ttf, f.ppem, truetype.Index(index), font.HintingFull); err != nil {
+		return err
+	}
+	for i := range f.buf.Points {
+		if i < len(f.xs) {
+			f.buf.Points[i].X = f.xs[i]
+		}
+	}
+	return nil
+}
+
+func (f *truetypeFace) outline(index int) (sfnt.Segments, error) {
+	if err := f.load(index); err != nil {
+		return nil, err
+	}
+	segments := make(sfnt.Segments, 0, len(f.buf.Points))
+	start := 0
+	for _, end := range f.buf.Ends {
+		segments = appendContour(segments, f.buf.Points[start:end])
+		start = end
+	}
+	return segments, nil
+}
+
+// appendContour converts a closed truetype contour into segments, flipping the
+// y axis.  The low bit of a point's flags tells whether it is on the curve; two
+// consecutive off curve points imply an on curve point between them.
+func appendContour(segments sfnt.Segments, ps []truetype.Point) sfnt.Segments {
+	if len(ps) == 0 {
+		return segments
+	}
+	point := func(p truetype.Point) fixed.Point26_6 {
+		return fixed.Point26_6{X: p.X, Y: -p.Y}
+	}
+	mid := func(a, b fixed.Point26_6) fixed.Point26_6 {
+		return fixed.Point26_6{X: (a.X + b.X) / 2, Y: (a.Y + b.Y) / 2}
+	}
+
+	start, others := point(ps[0]), ps[1:]
+	if ps[0].Flags&0x01 == 0 {
+		last := ps[len(ps)-1]
+		if last.Flags&0x01 != 0 {
+			start, others = point(last), ps[:len(ps)-1]
+		} else {
+			start, others = mid(start, point(last)), ps
+		}
+	}
+	segments = append(segments, sfnt.Segment{Op: sfnt.SegmentOpMoveTo, Args: [3]fixed.Point26_6{start}})
+
+	q0, on0 := start, true
+	for _, p := range others {
+		q, on := point(p), p.Flags&0x01 != 0
+		switch {
+		case on && on0:
+			segments = append(segments, sfnt.Segment{Op: sfnt.SegmentOpLineTo, Args: [3]fixed.Point26_6{q}})
+		case on:
+			segments = append(segments, sfnt.Segment{Op: sfnt.SegmentOpQuadTo, Args: [3]fixed.Point26_6{q0, q}})
+		case !on0:
+			segments = append(segments, sfnt.Segment{Op: sfnt.SegmentOpQuadTo, Args: [3]fixed.Point26_6{q0, mid(q0, q)}})
+		}
+		q0, on0 = q, on
+	}
+	if on0 {
+		return append(segments, sfnt.Segment{Op: sfnt.SegmentOpLineTo, Args: [3]fixed.Point26_6{start}})
+	}
+	return append(segments, sfnt.Segment{Op: sfnt.SegmentOpQuadTo, Args: [3]fixed.Point26_6{q0, start}})
+}
+
+type sfntFace struct {
+	font    *sfnt.Font
+	ppem    fixed.Int26_6
+	hinting font.Hinting
+	buf     sfnt.Buffer
+}
+
+func (f *sfntFace) index(ch rune) int {
+	index, err := f.font.GlyphIndex(&f.buf, ch)
+	if err != nil {
+		return 0
+	}
+	return int(index)
+}
+
+func (f *sfntFace) advance(index int) fixed.Int26_6 {
+	advance, err := f.font.GlyphAdvance(&f.buf, sfnt.GlyphIndex(index), f.ppem, f.hinting)
+	if err != nil {
+		return 0
+	}
+	return advance
+}
+
+func (f *sfntFace) bounds() fixed.Rectangle26_6 {
+	b, err := f.font.Bounds(&f.buf, f.ppem, f.hinting)
+	if err != nil {
+		return fixed.Rectangle26_6{}
+	}
+	return b
+}
+
+func (f *sfntFace) outline(index int) (sfnt.Segments, error) {
+	// the segments belong to the buffer and are only valid until its next use
+	return f.font.LoadGlyph(&f.buf, sfnt.GlyphIndex(index), f.ppem, nil)
+}
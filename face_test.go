@@ -0,0 +1,175 @@
+package gltext
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// makeCollection packs single face fonts into a TrueType collection.  Every
+// face gets its own copy of its tables.
+func makeCollection(t *testing.T, fonts ...[]byte) []byte {
+	be := binary.BigEndian
+	header := 12 + 4*len(fonts)
+	dataAt := header
+	for _, f := range fonts {
+		dataAt += 12 + 16*int(be.Uint16(f[4:]))
+	}
+
+	ttc := make([]byte, dataAt)
+	copy(ttc, "ttcf")
+	be.PutUint32(ttc[4:], 0x00010000)
+	be.PutUint32(ttc[8:], uint32(len(fonts)))
+
+	dirAt := header
+	for i, f := range fonts {
+		be.PutUint32(ttc[12+4*i:], uint32(dirAt))
+		numTables := int(be.Uint16(f[4:]))
+		copy(ttc[dirAt:], f[:12])
+		for j := 0; j < numTables; j++ {
+			record := f[12+16*j : 28+16*j]
+			offset, length := be.Uint32(record[8:]), be.Uint32(record[12:])
+			if int(offset+length) > len(f) {
+				t.Fatal("Table outside of the font.")
+			}
+			out := ttc[dirAt+12+16*j:]
+			copy(out, record)
+			be.PutUint32(out[8:], uint32(len(ttc)))
+			ttc = append(ttc, f[offset:offset+length]...)
+			for len(ttc)%4 != 0 {
+				ttc = append(ttc, 0)
+			}
+		}
+		dirAt += 12 + 16*numTables
+	}
+	return ttc
+}
+
+func TestFontCollection(t *testing.T) {
+	sans, err := ioutil.ReadFile("example/font/luxisr.ttf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mono, err := ioutil.ReadFile("example/font/luximr.ttf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ttc := makeCollection(t, sans, mono)
+
+	names, err := FontFaces(bytes.NewReader(ttc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 2 || names[0] != "Luxi Sans Regular" || names[1] != "Luxi Mono Regular" {
+		t.Errorf("Unexpected faces %q.", names)
+	}
+
+	ascii := RuneRanges{{Low: 32, High: 126}}
+	for i, single := range [][]byte{sans, mono} {
+		want, err := NewTruetypeFontConfig(bytes.NewReader(single), 24, ascii, 16, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := NewTruetypeFontConfigWithOptions(bytes.NewReader(ttc), 24, ascii, 16, 0, BakeOptions{FaceIndex: i})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the collection is read by sfnt rather than freetype, the metrics must agree
+		for j := range want.Glyphs {
+			if got.Glyphs[j] != want.Glyphs[j] {
+				t.Fatalf("Face %d glyph %d is %+v, expected %+v.", i, j, got.Glyphs[j], want.Glyphs[j])
+			}
+		}
+		if got.Image.Bounds() != want.Image.Bounds() {
+			t.Errorf("Face %d has image bounds %v, expected %v.", i, got.Image.Bounds(), want.Image.Bounds())
+		}
+	}
+
+	if _, err := NewTruetypeFontConfigWithOptions(bytes.NewReader(ttc), 24, ascii, 16, 0, BakeOptions{FaceIndex: 2}); err == nil {
+		t.Error("Expecting an error for a face outside of the collection.")
+	}
+	if _, err := NewTruetypeFontConfigWithOptions(bytes.NewReader(sans), 24, ascii, 16, 0, BakeOptions{FaceIndex: 1}); err == nil {
+		t.Error("Expecting an error for a second face of a single font.")
+	}
+
+	c, err := FontFaceCoverage(bytes.NewReader(ttc), 1, RuneRanges{{Low: 'a', High: 'z'}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Missing.RuneCount() != 0 {
+		t.Errorf("Unexpected missing runes %v.", c.Missing)
+	}
+}
+
+// CFFTest.otf has 1000 units per em.  Its "0" advances 600 units and its "1" 400,
+// the "1" being a rectangle from 100 to 300 units across and 0 to 800 units up.
+func TestCFFFont(t *testing.T) {
+	data, err := ioutil.ReadFile("example/font/CFFTest.otf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names, err := FontFaces(bytes.NewReader(data)); err != nil || len(names) != 1 || names[0] != "CFFTest" {
+		t.Errorf("Unexpected faces %q, %v.", names, err)
+	}
+
+	// at 100 pixels per em a unit is a tenth of a pixel
+	config, err := NewTruetypeFontConfig(bytes.NewReader(data), 100, RuneRanges{{Low: '0', High: '1'}}, 16, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Glyphs[0].Advance != 60 || config.Glyphs[1].Advance != 40 {
+		t.Fatalf("Unexpected advances %+v.", config.Glyphs)
+	}
+	one := config.Glyphs[1]
+	if config.Baseline != 80 {
+		t.Fatalf("Unexpected baseline %d.", config.Baseline)
+	}
+	for y := 0; y < config.Baseline; y++ {
+		for x := 0; x < one.Width; x++ {
+			_, _, _, a := config.Image.At(one.X+x, one.Y+y).RGBA()
+			if inside := x >= 10 && x < 30; inside != (a == 0xffff) {
+				t.Fatalf("Unexpected alpha %#x at %d, %d of the glyph \"1\".", a, x, y)
+			}
+		}
+	}
+}
+
+// The luxisr-12pt-*-hinting.txt files hold C freetype's advance, bounds and
+// points of every glyph at 12 pixels per em, one glyph per line.
+func TestHintedAdvances(t *testing.T) {
+	data, err := ioutil.ReadFile("example/font/luxisr.ttf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		file    string
+		hinting font.Hinting
+	}{
+		{"example/font/luxisr-12pt-sans-hinting.txt", font.HintingNone},
+		{"example/font/luxisr-12pt-with-hinting.txt", font.HintingFull},
+	} {
+		dump, err := ioutil.ReadFile(test.file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		face, err := parseFace(data, 0, 12<<6, test.hinting)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(strings.TrimSpace(string(dump)), "\n")[1:]
+		for index, line := range lines {
+			var want fixed.Int26_6
+			if _, err := fmt.Sscan(line, &want); err != nil {
+				t.Fatal(err)
+			}
+			if got := face.advance(index); got != want {
+				t.Errorf("%s: glyph %d has advance %v, expected %v.", test.file, index, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gltext
+
+import (
+	"bytes"
+	"errors"
+	"golang.org/x/image/math/fixed"
+	"strings"
+)
+
+// FontWeight is the thickness of a face's strokes on the CSS scale of 100 to 900.
+type FontWeight int
+
+const (
+	WeightThin    FontWeight = 100
+	WeightLight   FontWeight = 300
+	WeightRegular FontWeight = 400
+	WeightMedium  FontWeight = 500
+	WeightBold    FontWeight = 700
+	WeightBlack   FontWeight = 900
+)
+
+// FontStyle tells upright faces from slanted ones.
+type FontStyle uint8
+
+const (
+	StyleNormal FontStyle = iota
+	StyleItalic
+)
+
+// FontKey identifies a baked font by family name, weight, style and size.
+type FontKey struct {
+	Family string
+	Weight FontWeight
+	Style  FontStyle
+	Size   int // pixels per em
+}
+
+// Matches reports whether both keys name the same font, comparing families like
+// ClosestFont does: ignoring case and surrounding whitespace.
+func (k FontKey) Matches(other FontKey) bool {
+	return sameFamily(k.Family, other.Family) && k.Weight == other.Weight && k.Style == other.Style && k.Size == other.Size
+}
+
+// sameFamily compares family names ignoring case and surrounding whitespace.
+func sameFamily(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
+
+// weightDistance ranks how well the weight have stands in for want, lower is
+// better.  Like CSS, bold requests look for heavier faces first, light ones
+// for lighter faces, and regular requests for faces up to medium before
+// trying lighter and finally heavier ones.
+func weightDistance(want, have FontWeight) int {
+	diff := int(have - want)
+	switch {
+	case diff == 0:
+		return 0
+	case want > WeightMedium:
+		if diff > 0 {
+			return diff
+		}
+		return 1000 - diff
+	case want < WeightRegular:
+		if diff < 0 {
+			return -diff
+		}
+		return 1000 + diff
+	case have <= WeightMedium && diff > 0:
+		return diff
+	case diff < 0:
+		return 1000 - diff
+	}
+	return 2000 + diff
+}
+
+// sizeDistance ranks sizes by their difference to want, preferring larger
+// sizes on ties since scaling text down looks better than scaling it up.
+func sizeDistance(want, have int) int {
+	if have < want {
+		return 2*(want-have) + 1
+	}
+	return 2 * (have - want)
+}
+
+// better returns true when a matches want more closely than b.  Style matters
+// most, followed by weight and then size.
+func better(want, a, b FontKey) bool {
+	as, bs := a.Style != want.Style, b.Style != want.Style
+	if as != bs {
+		return !as
+	}
+	if aw, bw := weightDistance(want.Weight, a.Weight), weightDistance(want.Weight, b.Weight); aw != bw {
+		return aw < bw
+	}
+	return sizeDistance(want.Size, a.Size) < sizeDistance(want.Size, b.Size)
+}
+
+// ClosestFont returns the index of the font in have that matches want most
+// closely, or -1 when none belongs to the requested family.
+func ClosestFont(want FontKey, have []FontKey) int {
+	closest := -1
+	for i, key := range have {
+		if !sameFamily(key.Family, want.Family) {
+			continue
+		}
+		if closest < 0 || better(want, key, have[closest]) {
+			closest = i
+		}
+	}
+	return closest
+}
+
+// FontSource is a font file from which a face of a family can be baked at any
+// size.
+type FontSource struct {
+	Family string
+	Weight FontWeight
+	Style  FontStyle
+
+	// Data holds the TrueType or OpenType font file.
+	Data []byte
+
+	// RuneRanges, RunesPerRow and Options are passed on to
+	// NewTruetypeFontConfigWithOptions.  Sizes are in pixels, the DPI option
+	// is ignored.
+	RuneRanges  RuneRanges
+	RunesPerRow fixed.Int26_6
+	Options     BakeOptions
+}
+
+// Key returns the key of the source baked at the given size.
+func (s *FontSource) Key(size int) FontKey {
+	return FontKey{Family: s.Family, Weight: s.Weight, Style: s.Style, Size: size}
+}
+
+// ClosestSource returns the index of the source that matches want most closely,
+// or -1 when none belongs to the requested family.  Sources bake at any size so
+// only their style and weight are compared.
+func ClosestSource(want FontKey, sources []FontSource) int {
+	keys := make([]FontKey, len(sources))
+	for i := range sources {
+		keys[i] = sources[i].Key(want.Size)
+	}
+	return ClosestFont(want, keys)
+}
+
+// Bake creates the font config for key.Size pixels.  A bold or italic face is
+// synthesized when the key asks for a noticeably heavier weight or for an
+// italic style that the source lacks.
+func (s *FontSource) Bake(key FontKey) (*FontConfig, error) {
+	if key.Size <= 0 {
+		return nil, errors.New("The font size must be positive.")
+	}
+	runesPerRow := s.RunesPerRow
+	if runesPerRow <= 0 {
+		runesPerRow = 16
+	}
+	opts := s.Options
+	opts.DPI = 72
+	if key.Weight-s.Weight >= 200 && opts.Embolden == 0 {
+		// regular to bold adds about 4% of the font size to every stroke
+		opts.Embolden = float64(key.Weight-s.Weight) / 7500
+	}
+	if key.Style == StyleItalic && s.Style != StyleItalic && opts.Oblique == 0 {
+		opts.Oblique = 0.2
+	}
+	return NewTruetypeFontConfigWithOptions(bytes.NewReader(s.Data), fixed.Int26_6(key.Size), s.RuneRanges, runesPerRow, 0, opts)
+}
@@ -0,0 +1,81 @@
+package gltext
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestClosestFont(t *testing.T) {
+	have := []FontKey{
+		{"Noto Sans", WeightRegular, StyleNormal, 12},
+		{"Noto Sans", WeightRegular, StyleNormal, 24},
+		{"Noto Sans", WeightBold, StyleNormal, 16},
+		{"Noto Sans", WeightLight, StyleItalic, 16},
+		{"Noto Serif", WeightBold, StyleNormal, 18},
+	}
+	for _, test := range []struct {
+		want FontKey
+		at   int
+	}{
+		{FontKey{"noto sans ", WeightBold, StyleNormal, 18}, 2},
+		{FontKey{"Noto Sans", WeightRegular, StyleNormal, 18}, 1},
+		{FontKey{"Noto Sans", WeightRegular, StyleNormal, 13}, 0},
+		{FontKey{"Noto Sans", WeightBlack, StyleNormal, 12}, 2},
+		{FontKey{"Noto Sans", WeightRegular, StyleItalic, 12}, 3},
+		{FontKey{"Noto Sans", WeightThin, StyleNormal, 12}, 0},
+		{FontKey{"Noto Serif", WeightRegular, StyleNormal, 12}, 4},
+		{FontKey{"Noto Mono", WeightRegular, StyleNormal, 12}, -1},
+	} {
+		if at := ClosestFont(test.want, have); at != test.at {
+			t.Errorf("%+v matched %d, expected %d.", test.want, at, test.at)
+		}
+	}
+}
+
+func TestFontKeyMatches(t *testing.T) {
+	key := FontKey{"Noto Sans", WeightBold, StyleNormal, 16}
+	if !key.Matches(FontKey{" noto sans", WeightBold, StyleNormal, 16}) {
+		t.Error("Families should match ignoring case and whitespace")
+	}
+	for _, other := range []FontKey{
+		{"Noto Serif", WeightBold, StyleNormal, 16},
+		{"Noto Sans", WeightRegular, StyleNormal, 16},
+		{"Noto Sans", WeightBold, StyleItalic, 16},
+		{"Noto Sans", WeightBold, StyleNormal, 17},
+	} {
+		if key.Matches(other) {
+			t.Error("Unexpected match", other)
+		}
+	}
+}
+
+func TestFontSource(t *testing.T) {
+	data, err := ioutil.ReadFile("example/font/luxisr.ttf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sources := []FontSource{
+		{Family: "Luxi Sans", Weight: WeightRegular, Data: data, RuneRanges: RuneRanges{{Low: 'A', High: 'Z'}}},
+		{Family: "Luxi Mono", Weight: WeightRegular, Data: data},
+	}
+	bold := FontKey{"Luxi Sans", WeightBold, StyleItalic, 20}
+	i := ClosestSource(bold, sources)
+	if i != 0 {
+		t.Fatal("Unexpected source", i)
+	}
+	regular, err := sources[i].Bake(sources[i].Key(20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	synthesized, err := sources[i].Bake(bold)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, b := regular.Glyphs[regular.GlyphIndex('H')], synthesized.Glyphs[synthesized.GlyphIndex('H')]
+	if b.Advance <= r.Advance || b.Width <= r.Width {
+		t.Error("A bold italic face should have been synthesized", r, b)
+	}
+	if _, err := sources[i].Bake(FontKey{"Luxi Sans", WeightRegular, StyleNormal, 0}); err == nil {
+		t.Error("Expecting an error for an empty size.")
+	}
+}
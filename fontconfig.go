@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"image/draw"
 	"image/png"
 	"io/ioutil"
 	"os"
@@ -19,6 +20,26 @@ import (
 // Direction represents the direction in which strings should be rendered.
 type Direction uint8
 
+// ImageFormat describes how glyph coverage is stored in a font image.
+type ImageFormat uint8
+
+const (
+	// ImageFormatNRGBA stores white RGB values with coverage in the alpha channel.
+	ImageFormatNRGBA ImageFormat = iota
+	// ImageFormatAlpha stores coverage in a single alpha channel.
+	ImageFormatAlpha
+	// ImageFormatGray stores coverage as a single luminance channel.
+	ImageFormatGray
+)
+
+// BytesPerPixel returns the number of bytes used by a single pixel of the format.
+func (f ImageFormat) BytesPerPixel() int {
+	if f == ImageFormatNRGBA {
+		return 4
+	}
+	return 1
+}
+
 // FontConfig describes raster font metadata.
 //
 // It can be loaded from, or saved to a JSON encoded file,
@@ -26,15 +47,85 @@ type Direction uint8
 type FontConfig struct {
 	// The range of glyphs covered by this fontconfig
 	// An array of Low, High values allowing the user to select disjoint subsets of the ttf
+	// Change it through SetRuneRanges so that GlyphIndex stays fast.
 	RuneRanges RuneRanges
 
 	// Glyphs holds a set of glyph descriptors, defining the location,
 	// size and advance of each glyph in the sprite sheet.
 	Glyphs Charset
 
-	Image *image.NRGBA `json:"-"`
+	// Image holds the glyph sprite sheet.  Any image type may be supplied, it is
+	// converted to Format when the font is created.
+	Image image.Image `json:"-"`
+
+	// Format determines how the sprite sheet is stored in memory, on disk and on the gpu.
+	Format ImageFormat
+
+	// Replacement is drawn in place of runes that are neither found in RuneRanges
+	// nor in any fallback font.  Nil when no replacement glyph was baked.
+	Replacement *Glyph `json:",omitempty"`
+
+	// HexBox is set when Replacement is a box showing the missing code point.
+	HexBox *HexBox `json:",omitempty"`
+
+	// Baseline is the distance in pixels from the top of a glyph's cell to the
+	// baseline it was drawn on.  It allows glyphs of different fonts to share a
+	// line.  Zero when unknown, in which case the bottom of the cell is used.
+	Baseline int
+
+	// Padding is the number of texels separating every glyph from the cells
+	// around it in the sprite sheet.
+	Padding int `json:",omitempty"`
 
 	Name string
+
+	// index speeds up glyph lookups; see SetRuneRanges
+	index *RuneIndex
+}
+
+// SetRuneRanges replaces RuneRanges and builds the RuneIndex backing
+// GlyphIndex.  Baked and loaded configs are indexed already.
+func (fc *FontConfig) SetRuneRanges(rr RuneRanges) {
+	fc.RuneRanges = rr
+	fc.index = NewRuneIndex(rr)
+}
+
+// GlyphIndex returns the location of the rune's glyph within Glyphs, or -1
+// when the rune is not part of RuneRanges.  The lookup is backed by the
+// RuneIndex built by SetRuneRanges.  When RuneRanges was assigned directly it
+// falls back to RuneRanges.GetGlyphIndex.  Ranges edited in place are only
+// seen once they are passed to SetRuneRanges again.
+//
+// GlyphIndex does not modify the config, so it may be called concurrently.
+func (fc *FontConfig) GlyphIndex(char rune) rune {
+	if fc.index == nil || !fc.index.indexes(fc.RuneRanges) {
+		return fc.RuneRanges.GetGlyphIndex(char)
+	}
+	return fc.index.GlyphIndex(char)
+}
+
+// HasRune returns true when the font holds a glyph for the rune.
+func (fc *FontConfig) HasRune(char rune) bool {
+	return fc.GlyphIndex(char) >= 0
+}
+
+// Descent returns the distance in pixels from the bottom of the glyph's cell
+// up to the baseline.
+func (fc *FontConfig) Descent(g Glyph) int {
+	if fc.Baseline == 0 {
+		return 0
+	}
+	return g.Height - fc.Baseline
+}
+
+// TextureInset returns the number of texels by which texture coordinates are
+// moved into a glyph.  Padded sprite sheets are sampled half a texel inside
+// each glyph so that filtering never reaches a neighbouring glyph.
+func (fc *FontConfig) TextureInset() float32 {
+	if fc.Padding > 0 {
+		return 0.5
+	}
+	return 0
 }
 
 // Load reads font configuration data from the given JSON encoded stream.
@@ -48,11 +139,13 @@ func (fc *FontConfig) Load(rootPath string) (err error) {
 	if err != nil {
 		return err
 	}
+	fc.SetRuneRanges(fc.RuneRanges)
 	fmt.Printf("%+v\n", time.Now())
-	fc.Image, err = LoadFontImage(rootPath, fc.Name)
+	img, err := LoadFontImage(rootPath, fc.Name)
 	if err != nil {
 		return err
 	}
+	fc.Image = ConvertImage(img, fc.Format)
 	fmt.Printf("%+v\n", time.Now())
 	fc.Glyphs.Scale(1)
 	return nil
@@ -89,12 +182,17 @@ func (fc *FontConfig) Save(rootPath, name string) error {
 	return err
 }
 
-func LoadFontImage(rootPath, name string) (*image.NRGBA, error) {
+func LoadFontImage(rootPath, name string) (draw.Image, error) {
 	file := fmt.Sprintf("%s/%s.png", rootPath, name)
 	return LoadImage(file)
 }
 
-func SaveImage(rootPath, name string, img *image.NRGBA) error {
+// SaveImage writes the image as a png.  Alpha images are written as grayscale
+// so that the file stays single channel.
+func SaveImage(rootPath, name string, img image.Image) error {
+	if alpha, ok := img.(*image.Alpha); ok {
+		img = &image.Gray{Pix: alpha.Pix, Stride: alpha.Stride, Rect: alpha.Rect}
+	}
 	file := fmt.Sprintf("%s/%s.png", rootPath, name)
 	image, err := os.Create(file)
 	if err != nil {
@@ -0,0 +1,230 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gltext
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MarkupStyle is the style of a span of markup.
+type MarkupStyle struct {
+	Color     [4]float32 // rgba in the range of 0 to 1
+	Scale     float32
+	Bold      bool
+	Italic    bool
+	Underline bool
+	Effect    string // name of the animated effect, see MarkupEffects
+}
+
+// MarkupEffects are the effect tags understood by ParseMarkup.
+var MarkupEffects = []string{"wave", "shake", "rainbow"}
+
+// MarkupSpan is a piece of text sharing a single style.
+type MarkupSpan struct {
+	Text  string
+	Style MarkupStyle
+}
+
+// MarkupError reports malformed markup.  Line and Column are 1-based, columns
+// count runes.
+type MarkupError struct {
+	Offset int // byte offset of the offending tag
+	Line   int
+	Column int
+	Reason string
+}
+
+func (e *MarkupError) Error() string {
+	return fmt.Sprintf("Invalid markup at line %d, column %d: %s", e.Line, e.Column, e.Reason)
+}
+
+// markupColors are the color names accepted besides hex values.
+var markupColors = map[string][4]float32{
+	"white":   {1, 1, 1, 1},
+	"black":   {0, 0, 0, 1},
+	"gray":    {0.5, 0.5, 0.5, 1},
+	"red":     {1, 0, 0, 1},
+	"green":   {0, 1, 0, 1},
+	"blue":    {0, 0, 1, 1},
+	"yellow":  {1, 1, 0, 1},
+	"cyan":    {0, 1, 1, 1},
+	"magenta": {1, 0, 1, 1},
+}
+
+// markupTag is an open tag along with the style that was active before it.
+type markupTag struct {
+	name   string
+	offset int
+	style  MarkupStyle
+}
+
+// ParseMarkup splits s into spans styled by its tags, starting from the base
+// style.  The tags are
+//
+//	[b]bold[/b]  [i]italic[/i]  [u]underlined[/u]
+//	[color=#ff0]yellow[/color]  (#rgb, #rgba, #rrggbb, #rrggbbaa or a name such as red)
+//	[size=1.5]larger[/size]     (multiplies the enclosing size, must be positive and finite)
+//	[wave]wavy[/wave]  [shake]shaking[/shake]  [rainbow]colorful[/rainbow]
+//
+// Tags nest and must be closed in reverse order.  A literal "[" is written as
+// "[["; a "]" outside of a tag needs no escaping.  Empty spans are dropped and
+// neighbouring spans of equal style are merged.  Malformed markup results in a
+// *MarkupError locating the problem.
+func ParseMarkup(s string, base MarkupStyle) ([]MarkupSpan, error) {
+	spans := make([]MarkupSpan, 0)
+	stack := make([]markupTag, 0)
+	style := base
+	var text strings.Builder
+
+	flush := func() {
+		if text.Len() == 0 {
+			return
+		}
+		if n := len(spans); n > 0 && spans[n-1].Style == style {
+			spans[n-1].Text += text.String()
+		} else {
+			spans = append(spans, MarkupSpan{Text: text.String(), Style: style})
+		}
+		text.Reset()
+	}
+	fail := func(offset int, format string, argv ...interface{}) error {
+		line := 1 + strings.Count(s[:offset], "\n")
+		column := 1 + utf8.RuneCountInString(s[strings.LastIndex(s[:offset], "\n")+1:offset])
+		return &MarkupError{Offset: offset, Line: line, Column: column, Reason: fmt.Sprintf(format, argv...)}
+	}
+
+	for i := 0; i < len(s); {
+		open := strings.IndexByte(s[i:], '[')
+		if open < 0 {
+			text.WriteString(s[i:])
+			break
+		}
+		text.WriteString(s[i : i+open])
+		i += open
+		if strings.HasPrefix(s[i:], "[[") {
+			text.WriteByte('[')
+			i += 2
+			continue
+		}
+		end := strings.IndexByte(s[i:], ']')
+		if end < 0 {
+			return nil, fail(i, "the tag is never closed with \"]\".")
+		}
+		tag := s[i+1 : i+end]
+		at := i
+		i += end + 1
+
+		if strings.HasPrefix(tag, "/") {
+			name := tag[1:]
+			if len(stack) == 0 {
+				return nil, fail(at, "[/%s] closes a tag that was never opened.", name)
+			}
+			top := stack[len(stack)-1]
+			if top.name != name {
+				return nil, fail(at, "[/%s] found where [/%s] was expected.", name, top.name)
+			}
+			flush()
+			style = top.style
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		name, value := tag, ""
+		if eq := strings.IndexByte(tag, '='); eq >= 0 {
+			name, value = tag[:eq], tag[eq+1:]
+		}
+		next := style
+		switch name {
+		case "b":
+			next.Bold = true
+		case "i":
+			next.Italic = true
+		case "u":
+			next.Underline = true
+		case "color":
+			color, ok := parseMarkupColor(value)
+			if !ok {
+				return nil, fail(at, "invalid color %q.", value)
+			}
+			next.Color = color
+		case "size":
+			size, err := strconv.ParseFloat(value, 32)
+			next.Scale *= float32(size)
+			// NaN and infinities parse without an error, nested sizes may overflow
+			// or underflow to zero
+			if err != nil || !(size > 0) || !(next.Scale > 0) || math.IsInf(float64(next.Scale), 0) {
+				return nil, fail(at, "invalid size %q.", value)
+			}
+		default:
+			if !isMarkupEffect(name) {
+				return nil, fail(at, "unknown tag [%s].", tag)
+			}
+			next.Effect = name
+		}
+		if value != "" && name != "color" && name != "size" {
+			return nil, fail(at, "[%s] does not take a value.", name)
+		}
+		flush()
+		stack = append(stack, markupTag{name: name, offset: at, style: style})
+		style = next
+	}
+	if len(stack) > 0 {
+		top := stack[len(stack)-1]
+		return nil, fail(top.offset, "[%s] is never closed.", top.name)
+	}
+	flush()
+	return spans, nil
+}
+
+func isMarkupEffect(name string) bool {
+	for _, effect := range MarkupEffects {
+		if name == effect {
+			return true
+		}
+	}
+	return false
+}
+
+// parseMarkupColor parses a hex color of 3, 4, 6 or 8 digits following a "#",
+// or a color name.
+func parseMarkupColor(value string) ([4]float32, bool) {
+	if color, ok := markupColors[strings.ToLower(value)]; ok {
+		return color, true
+	}
+	if !strings.HasPrefix(value, "#") {
+		return [4]float32{}, false
+	}
+	hex := value[1:]
+	digits := 2
+	switch len(hex) {
+	case 3, 4:
+		digits = 1
+	case 6, 8:
+	default:
+		return [4]float32{}, false
+	}
+	color := [4]float32{1, 1, 1, 1}
+	for c := 0; c*digits < len(hex); c++ {
+		v, err := strconv.ParseUint(hex[c*digits:(c+1)*digits], 16, 8)
+		if err != nil {
+			return [4]float32{}, false
+		}
+		if digits == 1 {
+			v *= 17
+		}
+		color[c] = float32(v) / 255
+	}
+	return color, true
+}
+
+// EscapeMarkup escapes the "[" of s so that ParseMarkup returns s unchanged.
+// Use it for values inserted into markup, such as player names.
+func EscapeMarkup(s string) string {
+	return strings.Replace(s, "[", "[[", -1)
+}
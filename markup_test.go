@@ -0,0 +1,79 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gltext
+
+import (
+	"testing"
+)
+
+func TestParseMarkup(t *testing.T) {
+	base := MarkupStyle{Color: [4]float32{1, 1, 1, 1}, Scale: 1}
+	spans, err := ParseMarkup("[color=#ff0]Warning[/color] [b]bold [size=1.5]big[/size][/b][[x]", base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spans) != 5 {
+		t.Fatal("Unexpected spans", spans)
+	}
+	if s := spans[0]; s.Text != "Warning" || s.Style.Color != [4]float32{1, 1, 0, 1} || s.Style.Bold {
+		t.Error("Unexpected color span", s)
+	}
+	if s := spans[1]; s.Text != " " || s.Style != base {
+		t.Error("Closing a tag should restore the enclosing style", s)
+	}
+	if s := spans[2]; s.Text != "bold " || !s.Style.Bold || s.Style.Scale != 1 {
+		t.Error("Unexpected bold span", s)
+	}
+	if s := spans[3]; s.Text != "big" || !s.Style.Bold || s.Style.Scale != 1.5 {
+		t.Error("Nested tags should combine", s)
+	}
+	if s := spans[4]; s.Text != "[x]" || s.Style != base {
+		t.Error("\"[[\" should escape a bracket", s)
+	}
+
+	spans, err = ParseMarkup("[size=2][size=2][color=#00000080]a[/color][/size][/size]", base)
+	if err != nil || spans[0].Style.Scale != 4 || spans[0].Style.Color[3] != 128.0/255 {
+		t.Error("Unexpected nested sizes or alpha", spans, err)
+	}
+	if spans, err := ParseMarkup(EscapeMarkup("[b]"), base); err != nil || len(spans) != 1 || spans[0].Text != "[b]" {
+		t.Error("Escaped markup should be kept as is", spans, err)
+	}
+	if spans, err := ParseMarkup("[wave]a[shake]b[/shake][/wave]", base); err != nil || spans[0].Style.Effect != "wave" || spans[1].Style.Effect != "shake" {
+		t.Error("Unexpected effects", spans, err)
+	}
+	if spans, err := ParseMarkup("[u][/u]", base); err != nil || len(spans) != 0 {
+		t.Error("Empty spans should be dropped", spans, err)
+	}
+
+	errors := []struct {
+		markup       string
+		line, column int
+	}{
+		{"ok\nfine [b]x", 2, 6},
+		{"[b]x[/i][/b]", 1, 5},
+		{"x[/b]", 1, 2},
+		{"é[color=#ggg]x[/color]", 1, 2},
+		{"[size=0]x[/size]", 1, 1},
+		{"[size=NaN]x[/size]", 1, 1},
+		{"[size=Inf]x[/size]", 1, 1},
+		{"[size=1e30][size=1e30]x[/size][/size]", 1, 12},
+		{"[size=1e-30][size=1e-30]x[/size][/size]", 1, 13},
+		{"[blink]x[/blink]", 1, 1},
+		{"[b=1]x[/b]", 1, 1},
+		{"[wave=2]x[/wave]", 1, 1},
+		{"ab[b", 1, 3},
+	}
+	for _, e := range errors {
+		_, err := ParseMarkup(e.markup, base)
+		me, ok := err.(*MarkupError)
+		if !ok {
+			t.Errorf("Expecting a markup error for %q, got %v", e.markup, err)
+			continue
+		}
+		if me.Line != e.line || me.Column != e.column {
+			t.Errorf("Unexpected position of %q: %v", e.markup, me)
+		}
+	}
+}
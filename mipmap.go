@@ -0,0 +1,81 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gltext
+
+import (
+	"image"
+	"image/draw"
+)
+
+// MaxMipmapLevel returns the smallest mipmap level that can be sampled from a
+// sprite sheet baked with the given padding without picking up neighbouring
+// glyphs.  A padding of 2^n texels allows n levels.  Sprite sheets without
+// padding allow no levels besides the full size image.
+func MaxMipmapLevel(padding int) int {
+	if padding <= 0 {
+		return 0
+	}
+	level := 0
+	for padding > 1 {
+		padding >>= 1
+		level++
+	}
+	return level
+}
+
+// HalveImage returns a font image at half the width and height of img, as used
+// for the next mipmap level.  Every pixel averages a block of 2x2 pixels.  The
+// format of the image is preserved.
+func HalveImage(img image.Image) (draw.Image, error) {
+	pix, format, err := ImagePixels(img)
+	if err != nil {
+		return nil, err
+	}
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	hw, hh := (w+1)/2, (h+1)/2
+	bpp := format.BytesPerPixel()
+
+	half := make([]uint8, hw*hh*bpp)
+	for y := 0; y < hh; y++ {
+		for x := 0; x < hw; x++ {
+			for c := 0; c < bpp; c++ {
+				sum, n := 0, 0
+				for sy := 2 * y; sy < 2*y+2 && sy < h; sy++ {
+					for sx := 2 * x; sx < 2*x+2 && sx < w; sx++ {
+						sum += int(pix[(sy*w+sx)*bpp+c])
+						n++
+					}
+				}
+				half[(y*hw+x)*bpp+c] = uint8((sum + n/2) / n)
+			}
+		}
+	}
+
+	rect := image.Rect(0, 0, hw, hh)
+	switch format {
+	case ImageFormatAlpha:
+		return &image.Alpha{Pix: half, Stride: hw, Rect: rect}, nil
+	case ImageFormatGray:
+		return &image.Gray{Pix: half, Stride: hw, Rect: rect}, nil
+	}
+	return &image.NRGBA{Pix: half, Stride: hw * 4, Rect: rect}, nil
+}
+
+// MipmapChain returns the mipmap levels below img, starting with level 1, down
+// to a single pixel or maxLevel, whichever comes first.
+func MipmapChain(img image.Image, maxLevel int) ([]draw.Image, error) {
+	levels := make([]draw.Image, 0)
+	b := img.Bounds()
+	for level := 1; level <= maxLevel && (b.Dx() > 1 || b.Dy() > 1); level++ {
+		half, err := HalveImage(img)
+		if err != nil {
+			return nil, err
+		}
+		levels = append(levels, half)
+		img, b = half, half.Bounds()
+	}
+	return levels, nil
+}
@@ -5,10 +5,12 @@
 package gltext
 
 import (
-	"errors"
 	"fmt"
+	_ "golang.org/x/image/bmp"
 	"image"
 	"image/color"
+	"image/draw"
+	_ "image/jpeg"
 	"os"
 )
 
@@ -95,18 +97,143 @@ func copyImg(src, dst copyable) image.Image {
 	return dst
 }
 
-func LoadImage(path string) (*image.NRGBA, error) {
+// LoadImage decodes the image found at path.  Any format registered with the
+// image package may be used; png, jpeg, bmp and tga are registered by gltext.
+// Images that are not already stored as NRGBA, alpha or grayscale are
+// converted to NRGBA using ConvertImage.
+func LoadImage(path string) (draw.Image, error) {
 	img, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
+	defer img.Close()
+
 	pix, _, err := image.Decode(img)
 	if err != nil {
 		return nil, err
 	}
-	p, ok := pix.(*image.NRGBA)
-	if ok {
+	switch p := pix.(type) {
+	case *image.NRGBA:
+		return p, nil
+	case *image.Alpha:
+		return p, nil
+	case *image.Gray:
 		return p, nil
 	}
-	return nil, errors.New("Not a NRGBA image.")
+	return ConvertImage(pix, ImageFormatNRGBA), nil
+}
+
+// ConvertImage returns src stored using the given format.  Images with
+// transparency and single channel images that are already stored in that
+// format are returned as they are.
+//
+// Coverage is taken from the alpha channel of images with transparency.  Opaque
+// images, such as grayscale, jpeg or fully opaque NRGBA sprite sheets, have no
+// usable alpha channel so their luminance is treated as coverage instead: white
+// glyphs on a black background.
+func ConvertImage(src image.Image, format ImageFormat) draw.Image {
+	switch src := src.(type) {
+	case *image.NRGBA:
+		if src.Opaque() {
+			break
+		}
+		if format == ImageFormatNRGBA {
+			return src
+		}
+		mask := image.NewAlpha(src.Bounds())
+		for y := src.Rect.Min.Y; y < src.Rect.Max.Y; y++ {
+			for x := src.Rect.Min.X; x < src.Rect.Max.X; x++ {
+				mask.Pix[mask.PixOffset(x, y)] = src.Pix[src.PixOffset(x, y)+3]
+			}
+		}
+		return coverageImage(mask.Pix, mask.Stride, mask.Rect, format)
+	case *image.Alpha:
+		if format == ImageFormatAlpha {
+			return src
+		}
+		return coverageImage(src.Pix, src.Stride, src.Rect, format)
+	case *image.Gray:
+		if format == ImageFormatGray {
+			return src
+		}
+		return coverageImage(src.Pix, src.Stride, src.Rect, format)
+	}
+
+	rect := src.Bounds()
+	opaque := false
+	if o, ok := src.(interface {
+		Opaque() bool
+	}); ok {
+		opaque = o.Opaque()
+	}
+	if !opaque && format == ImageFormatNRGBA {
+		dst := image.NewNRGBA(rect)
+		draw.Draw(dst, rect, src, rect.Min, draw.Src)
+		return dst
+	}
+
+	mask := image.NewAlpha(rect)
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			c := src.At(x, y)
+			if opaque {
+				mask.Pix[mask.PixOffset(x, y)] = color.GrayModel.Convert(c).(color.Gray).Y
+			} else {
+				mask.Pix[mask.PixOffset(x, y)] = color.AlphaModel.Convert(c).(color.Alpha).A
+			}
+		}
+	}
+	return coverageImage(mask.Pix, mask.Stride, mask.Rect, format)
+}
+
+// coverageImage wraps single channel coverage data using the given format.
+func coverageImage(coverage []uint8, stride int, rect image.Rectangle, format ImageFormat) draw.Image {
+	switch format {
+	case ImageFormatAlpha:
+		return &image.Alpha{Pix: coverage, Stride: stride, Rect: rect}
+	case ImageFormatGray:
+		return &image.Gray{Pix: coverage, Stride: stride, Rect: rect}
+	}
+	dst := image.NewNRGBA(rect)
+	for y := 0; y < rect.Dy(); y++ {
+		for x := 0; x < rect.Dx(); x++ {
+			a := coverage[y*stride+x]
+			if a == 0 {
+				continue
+			}
+			i := y*dst.Stride + x*4
+			dst.Pix[i], dst.Pix[i+1], dst.Pix[i+2], dst.Pix[i+3] = 0xff, 0xff, 0xff, a
+		}
+	}
+	return dst
+}
+
+// ImagePixels returns the tightly packed pixel data of a font image along with
+// its format, ready to be uploaded as a texture.
+func ImagePixels(img image.Image) ([]uint8, ImageFormat, error) {
+	var pix []uint8
+	var stride int
+	var format ImageFormat
+
+	switch src := img.(type) {
+	case *image.NRGBA:
+		pix, stride, format = src.Pix, src.Stride, ImageFormatNRGBA
+	case *image.Alpha:
+		pix, stride, format = src.Pix, src.Stride, ImageFormatAlpha
+	case *image.Gray:
+		pix, stride, format = src.Pix, src.Stride, ImageFormatGray
+	default:
+		return nil, format, fmt.Errorf("Unsupported image type: %T", img)
+	}
+
+	rowLength := img.Bounds().Dx() * format.BytesPerPixel()
+	height := img.Bounds().Dy()
+	if stride == rowLength {
+		return pix[:rowLength*height], format, nil
+	}
+	packed := make([]uint8, rowLength*height)
+	for y := 0; y < height; y++ {
+		copy(packed[y*rowLength:(y+1)*rowLength], pix[y*stride:])
+	}
+	return packed, format, nil
 }
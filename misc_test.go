@@ -0,0 +1,103 @@
+package gltext
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestConvertPalettedImage(t *testing.T) {
+	palette := color.Palette{color.Transparent, color.NRGBA{255, 0, 0, 128}}
+	src := image.NewPaletted(image.Rect(0, 0, 2, 1), palette)
+	src.SetColorIndex(1, 0, 1)
+
+	dst, ok := ConvertImage(src, ImageFormatAlpha).(*image.Alpha)
+	if !ok {
+		t.Fatal("Expecting an alpha image.")
+	}
+	if dst.Pix[0] != 0 || dst.Pix[1] != 128 {
+		t.Error("Coverage should come from the alpha channel", dst.Pix)
+	}
+}
+
+func TestConvertOpaqueImage(t *testing.T) {
+	src := image.NewGray(image.Rect(0, 0, 2, 1))
+	src.Pix[1] = 200
+
+	dst, ok := ConvertImage(src, ImageFormatNRGBA).(*image.NRGBA)
+	if !ok {
+		t.Fatal("Expecting an NRGBA image.")
+	}
+	if c := dst.NRGBAAt(0, 0); c.A != 0 {
+		t.Error("Black should be transparent", c)
+	}
+	if c := dst.NRGBAAt(1, 0); c != (color.NRGBA{255, 255, 255, 200}) {
+		t.Error("Luminance should become coverage", c)
+	}
+
+	nrgba := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	nrgba.SetNRGBA(0, 0, color.NRGBA{0, 0, 0, 255})
+	nrgba.SetNRGBA(1, 0, color.NRGBA{200, 200, 200, 255})
+	for _, format := range []ImageFormat{ImageFormatNRGBA, ImageFormatAlpha} {
+		_, _, _, a0 := ConvertImage(nrgba, format).At(0, 0).RGBA()
+		_, _, _, a1 := ConvertImage(nrgba, format).At(1, 0).RGBA()
+		if a0 != 0 || a1>>8 != 200 {
+			t.Error("Luminance of opaque NRGBA images should become coverage", format, a0, a1)
+		}
+	}
+
+	ycbcr := image.NewYCbCr(image.Rect(0, 0, 1, 1), image.YCbCrSubsampleRatio444)
+	ycbcr.Y[0] = 255
+	ycbcr.Cb[0], ycbcr.Cr[0] = 128, 128
+	alpha := ConvertImage(ycbcr, ImageFormatAlpha).(*image.Alpha)
+	if alpha.Pix[0] != 255 {
+		t.Error("White jpeg pixel should be fully covered", alpha.Pix[0])
+	}
+}
+
+func TestMipmapChain(t *testing.T) {
+	src := image.NewAlpha(image.Rect(0, 0, 4, 2))
+	copy(src.Pix, []uint8{
+		255, 255, 0, 100,
+		255, 255, 0, 0,
+	})
+	levels, err := MipmapChain(src, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(levels) != 2 {
+		t.Fatal("Expecting two levels down to a single pixel", len(levels))
+	}
+	half, ok := levels[0].(*image.Alpha)
+	if !ok || half.Rect.Dx() != 2 || half.Rect.Dy() != 1 {
+		t.Fatal("Unexpected first level", levels[0].Bounds())
+	}
+	if half.Pix[0] != 255 || half.Pix[1] != 25 {
+		t.Error("Unexpected averages", half.Pix)
+	}
+	if levels, _ := MipmapChain(src, 1); len(levels) != 1 {
+		t.Error("Expecting the chain to stop at the maximum level", len(levels))
+	}
+
+	for padding, want := range map[int]int{0: 0, 1: 0, 2: 1, 3: 1, 4: 2, 8: 3} {
+		if got := MaxMipmapLevel(padding); got != want {
+			t.Errorf("A padding of %d allows %d levels, expected %d.", padding, got, want)
+		}
+	}
+}
+
+func TestEasing(t *testing.T) {
+	easings := []Easing{Linear, EaseInQuad, EaseOutQuad, EaseInOutQuad, EaseInCubic, EaseOutCubic, EaseInOutCubic, EaseInOutSine, EaseOutBack}
+	for i, easing := range easings {
+		if start, end := easing(0), easing(1); math.Abs(float64(start)) > 1e-6 || math.Abs(float64(end-1)) > 1e-6 {
+			t.Error("Easings should start at 0 and end at 1", i, start, end)
+		}
+	}
+	if EaseInQuad(0.5) >= 0.5 || EaseOutQuad(0.5) <= 0.5 || EaseInOutCubic(0.5) != 0.5 {
+		t.Error("Unexpected easing halfway")
+	}
+	if EaseOutBack(0.8) <= 1 {
+		t.Error("EaseOutBack should overshoot")
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gltext
+
+import (
+	"math"
+	"sort"
+)
+
+// curveSegments is the number of line segments a Bézier curve is flattened into.
+const curveSegments = 128
+
+// Path is a curve that text can be laid out along, stored as a polyline.  Distances
+// along it are measured in pixels from its first point.
+type Path struct {
+	points  []Point
+	lengths []float32 // distance of each point from the first one
+}
+
+// Polyline returns the path through the points.
+func Polyline(points ...Point) *Path {
+	p := &Path{}
+	for _, point := range points {
+		p.add(point)
+	}
+	return p
+}
+
+// QuadraticBezier returns the quadratic Bézier curve from p0 to p2 with the control point p1.
+func QuadraticBezier(p0, p1, p2 Point) *Path {
+	p := &Path{}
+	for i := 0; i <= curveSegments; i++ {
+		t := float32(i) / curveSegments
+		u := 1 - t
+		p.add(Point{
+			X: u*u*p0.X + 2*u*t*p1.X + t*t*p2.X,
+			Y: u*u*p0.Y + 2*u*t*p1.Y + t*t*p2.Y,
+		})
+	}
+	return p
+}
+
+// CubicBezier returns the cubic Bézier curve from p0 to p3 with the control points p1 and p2.
+func CubicBezier(p0, p1, p2, p3 Point) *Path {
+	p := &Path{}
+	for i := 0; i <= curveSegments; i++ {
+		t := float32(i) / curveSegments
+		u := 1 - t
+		p.add(Point{
+			X: u*u*u*p0.X + 3*u*u*t*p1.X + 3*u*t*t*p2.X + t*t*t*p3.X,
+			Y: u*u*u*p0.Y + 3*u*u*t*p1.Y + 3*u*t*t*p2.Y + t*t*t*p3.Y,
+		})
+	}
+	return p
+}
+
+// Arc returns the arc of the circle around center from the angle start to end, in
+// radians counter-clockwise from the x axis.  Glyphs along counter-clockwise arcs have
+// their tops towards the center.  Arcs whose end is smaller than their start run
+// clockwise and carry text on the outside, like a title over the top of a circle.
+func Arc(center Point, radius, start, end float32) *Path {
+	p := &Path{}
+	sweep := float64(end - start)
+	n := int(math.Ceil(math.Abs(sweep) / (math.Pi / 128)))
+	if n < 1 {
+		n = 1
+	}
+	for i := 0; i <= n; i++ {
+		a := float64(start) + sweep*float64(i)/float64(n)
+		p.add(Point{
+			X: center.X + radius*float32(math.Cos(a)),
+			Y: center.Y + radius*float32(math.Sin(a)),
+		})
+	}
+	return p
+}
+
+// add appends a point, skipping duplicates that have no direction.
+func (p *Path) add(point Point) {
+	n := len(p.points)
+	if n == 0 {
+		p.points = append(p.points, point)
+		p.lengths = append(p.lengths, 0)
+		return
+	}
+	last := p.points[n-1]
+	d := float32(math.Hypot(float64(point.X-last.X), float64(point.Y-last.Y)))
+	if d == 0 {
+		return
+	}
+	p.points = append(p.points, point)
+	p.lengths = append(p.lengths, p.lengths[n-1]+d)
+}
+
+// Length returns the length of the path.
+func (p *Path) Length() float32 {
+	if len(p.lengths) == 0 {
+		return 0
+	}
+	return p.lengths[len(p.lengths)-1]
+}
+
+// At returns the point at the distance along the path and the angle of the path's
+// direction there, in radians counter-clockwise from the x axis.  Distances before the
+// start or beyond the end continue along the first or last segment.
+func (p *Path) At(distance float32) (point Point, angle float32) {
+	switch len(p.points) {
+	case 0:
+		return Point{}, 0
+	case 1:
+		return Point{X: p.points[0].X + distance, Y: p.points[0].Y}, 0
+	}
+	// the segment ending at the first point further along than distance
+	i := sort.Search(len(p.lengths), func(i int) bool { return p.lengths[i] > distance })
+	if i == 0 {
+		i = 1
+	} else if i == len(p.lengths) {
+		i = len(p.lengths) - 1
+	}
+	a, b := p.points[i-1], p.points[i]
+	l := p.lengths[i] - p.lengths[i-1]
+	f := (distance - p.lengths[i-1]) / l
+	point = Point{X: a.X + (b.X-a.X)*f, Y: a.Y + (b.Y-a.Y)*f}
+	angle = float32(math.Atan2(float64(b.Y-a.Y), float64(b.X-a.X)))
+	return
+}
@@ -0,0 +1,58 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gltext
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPath(t *testing.T) {
+	// curves are flattened into short lines
+	near := func(a, b float32) bool {
+		return math.Abs(float64(a-b)) < 0.05
+	}
+
+	p := Polyline(Point{0, 0}, Point{10, 0}, Point{10, 0}, Point{10, 20})
+	if p.Length() != 30 {
+		t.Error("Unexpected length", p.Length())
+	}
+	if at, angle := p.At(5); at != (Point{5, 0}) || angle != 0 {
+		t.Error("Unexpected point on the first segment", at, angle)
+	}
+	if at, angle := p.At(20); at != (Point{10, 10}) || !near(angle, math.Pi/2) {
+		t.Error("Unexpected point on the second segment", at, angle)
+	}
+	if at, _ := p.At(-5); at != (Point{-5, 0}) {
+		t.Error("The path should extend before its start", at)
+	}
+	if at, _ := p.At(40); at != (Point{10, 30}) {
+		t.Error("The path should extend beyond its end", at)
+	}
+
+	// a half circle running clockwise over the top
+	arc := Arc(Point{0, 0}, 100, math.Pi, 0)
+	if !near(arc.Length(), 100*math.Pi) {
+		t.Error("Unexpected arc length", arc.Length())
+	}
+	if at, angle := arc.At(arc.Length() / 2); !near(at.X, 0) || !near(at.Y, 100) || !near(angle, 0) {
+		t.Error("Unexpected top of the arc", at, angle)
+	}
+
+	q := QuadraticBezier(Point{0, 0}, Point{50, 100}, Point{100, 0})
+	if at, angle := q.At(q.Length() / 2); !near(at.X, 50) || !near(at.Y, 50) || !near(angle, 0) {
+		t.Error("Unexpected middle of the quadratic curve", at, angle)
+	}
+	c := CubicBezier(Point{0, 0}, Point{0, 50}, Point{100, 50}, Point{100, 0})
+	if at, _ := c.At(0); at != (Point{0, 0}) {
+		t.Error("Unexpected start of the cubic curve", at)
+	}
+	if _, angle := c.At(0.1); !near(angle, math.Pi/2) {
+		t.Error("The cubic curve should start upwards", angle)
+	}
+	if at, _ := c.At(c.Length()); !near(at.X, 100) || !near(at.Y, 0) {
+		t.Error("Unexpected end of the cubic curve", at)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gltext
+
+import (
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+	"image"
+	"image/draw"
+	"math"
+)
+
+// rasterizer fills glyph outlines into an alpha mask.
+type rasterizer struct {
+	z vector.Rasterizer
+
+	// samples per pixel along each axis, coverage is averaged when above one
+	samples int
+	scratch *image.Alpha
+}
+
+// draw fills the outline with the glyph's origin at pixel (x, y) of the mask.
+// Only the area covered by the outline and the clip rectangle is rasterized.
+func (r *rasterizer) draw(mask *image.Alpha, clip image.Rectangle, segments sfnt.Segments, x, y int) {
+	b := segments.Bounds()
+	dr := image.Rect(
+		x+b.Min.X.Floor(), y+b.Min.Y.Floor(),
+		x+b.Max.X.Ceil(), y+b.Max.Y.Ceil(),
+	).Intersect(clip).Intersect(mask.Rect)
+	if dr.Empty() {
+		return
+	}
+	s := r.samples
+	if s < 1 {
+		s = 1
+	}
+	r.z.Reset(dr.Dx()*s, dr.Dy()*s)
+
+	ox, oy := float32(x-dr.Min.X), float32(y-dr.Min.Y)
+	at := func(p fixed.Point26_6) (float32, float32) {
+		return float32(s) * (ox + float32(p.X)/64), float32(s) * (oy + float32(p.Y)/64)
+	}
+	for i, seg := range segments {
+		switch seg.Op {
+		case sfnt.SegmentOpMoveTo:
+			if i > 0 {
+				r.z.ClosePath()
+			}
+			r.z.MoveTo(at(seg.Args[0]))
+		case sfnt.SegmentOpLineTo:
+			r.z.LineTo(at(seg.Args[0]))
+		case sfnt.SegmentOpQuadTo:
+			bx, by := at(seg.Args[0])
+			cx, cy := at(seg.Args[1])
+			r.z.QuadTo(bx, by, cx, cy)
+		case sfnt.SegmentOpCubeTo:
+			bx, by := at(seg.Args[0])
+			cx, cy := at(seg.Args[1])
+			dx, dy := at(seg.Args[2])
+			r.z.CubeTo(bx, by, cx, cy, dx, dy)
+		}
+	}
+	r.z.ClosePath()
+
+	if s == 1 {
+		r.z.Draw(mask, dr, image.Opaque, image.Point{})
+		return
+	}
+	sr := image.Rect(0, 0, dr.Dx()*s, dr.Dy()*s)
+	if r.scratch == nil || !sr.In(r.scratch.Rect) {
+		r.scratch = image.NewAlpha(sr)
+	}
+	r.z.DrawOp = draw.Src
+	r.z.Draw(r.scratch, sr, image.Opaque, image.Point{})
+	downsample(mask, dr, r.scratch, s)
+}
+
+// downsample averages blocks of s*s samples of src into the pixels of dr,
+// drawing them over the existing coverage.
+func downsample(dst *image.Alpha, dr image.Rectangle, src *image.Alpha, s int) {
+	n := uint32(s * s)
+	for y := 0; y < dr.Dy(); y++ {
+		for x := 0; x < dr.Dx(); x++ {
+			var sum uint32
+			for sy := 0; sy < s; sy++ {
+				row := src.Pix[src.PixOffset(x*s, y*s+sy):]
+				for sx := 0; sx < s; sx++ {
+					sum += uint32(row[sx])
+				}
+			}
+			a := (sum + n/2) / n
+			i := dst.PixOffset(dr.Min.X+x, dr.Min.Y+y)
+			d := uint32(dst.Pix[i])
+			dst.Pix[i] = uint8(a + (d*(255-a)+127)/255)
+		}
+	}
+}
+
+// extrude copies the pixels along the edges of r outwards into the n pixels
+// surrounding it, corners included.  Filtering and mipmapping across the edge
+// of r then blend with copies of the edge rather than with empty space.
+func extrude(mask *image.Alpha, r image.Rectangle, n int) {
+	r = r.Intersect(mask.Rect)
+	if r.Empty() {
+		return
+	}
+	outer := r.Inset(-n).Intersect(mask.Rect)
+	for y := outer.Min.Y; y < outer.Max.Y; y++ {
+		sy := clampInt(y, r.Min.Y, r.Max.Y-1)
+		for x := outer.Min.X; x < outer.Max.X; x++ {
+			if y == sy && x >= r.Min.X && x < r.Max.X {
+				continue
+			}
+			sx := clampInt(x, r.Min.X, r.Max.X-1)
+			mask.Pix[mask.PixOffset(x, y)] = mask.Pix[mask.PixOffset(sx, sy)]
+		}
+	}
+}
+
+func clampInt(v, low, high int) int {
+	if v < low {
+		return low
+	}
+	if v > high {
+		return high
+	}
+	return v
+}
+
+// adjustGamma maps the coverage of every pixel through coverage^(1/gamma).
+// Values above one darken and thicken strokes, values below one lighten them.
+func adjustGamma(mask *image.Alpha, gamma float64) {
+	if gamma <= 0 || gamma == 1 {
+		return
+	}
+	var table [256]uint8
+	for i := range table {
+		table[i] = uint8(math.Pow(float64(i)/255, 1/gamma)*255 + 0.5)
+	}
+	for i, a := range mask.Pix {
+		mask.Pix[i] = table[a]
+	}
+}
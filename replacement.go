@@ -0,0 +1,138 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gltext
+
+import (
+	"image"
+)
+
+// ReplacementStyle selects the glyph that is baked for runes a font cannot draw.
+type ReplacementStyle uint8
+
+const (
+	// ReplacementNotdef uses the font's own .notdef glyph, usually an empty box.
+	ReplacementNotdef ReplacementStyle = iota
+	// ReplacementCharacter uses U+FFFD, falling back to .notdef when the font lacks it.
+	ReplacementCharacter
+	// ReplacementHexBox draws a box showing the code point of the missing rune.
+	ReplacementHexBox
+	// ReplacementNone bakes no replacement glyph.  Missing runes take up no space.
+	ReplacementNone
+)
+
+// HexBox describes the replacement glyph of ReplacementHexBox.  The box itself
+// is the FontConfig's Replacement glyph; the code point is drawn inside of it
+// using one glyph per hex digit, in two rows of up to three digits.
+type HexBox struct {
+	// Digits holds the glyphs for 0-9 followed by A-F.
+	Digits Charset
+
+	// Top is the distance in pixels from the top of the replacement glyph's
+	// cell to the top of the box.
+	Top int
+
+	// Unit is the size in pixels of a single pixel of the digits.
+	Unit int
+}
+
+// A HexDigit is a digit glyph placed within a hex box.
+type HexDigit struct {
+	Glyph Glyph
+	X, Y  int // offset in pixels from the top left of the replacement glyph's cell
+}
+
+// Layout returns the digits showing the code point of char.  Runes of the basic
+// multilingual plane show four digits, all others six.
+func (hb *HexBox) Layout(char rune) []HexDigit {
+	columns, count := 2, 4
+	if char > 0xffff {
+		columns, count = 3, 6
+	}
+	// the box has room for three columns, narrower layouts are centered
+	left := hexBoxBorder*hb.Unit + (3-columns)*hexDigitAdvance*hb.Unit/2
+	top := hb.Top + hexBoxBorder*hb.Unit
+
+	digits := make([]HexDigit, count)
+	for i := range digits {
+		shift := uint(4 * (count - 1 - i))
+		digits[i] = HexDigit{
+			Glyph: hb.Digits[(char>>shift)&0xf],
+			X:     left + (i%columns)*hexDigitAdvance*hb.Unit,
+			Y:     top + (i/columns)*hexDigitLineHeight*hb.Unit,
+		}
+	}
+	return digits
+}
+
+// hex digits are drawn from a 3x5 pixel font, scaled by the box's unit
+const (
+	hexDigitWidth      = 3
+	hexDigitHeight     = 5
+	hexDigitAdvance    = hexDigitWidth + 1
+	hexDigitLineHeight = hexDigitHeight + 1
+	hexBoxBorder       = 2 // frame plus padding around the digits
+	hexBoxWidth        = 2*hexBoxBorder + 3*hexDigitAdvance - 1
+	hexBoxHeight       = 2*hexBoxBorder + 2*hexDigitLineHeight - 1
+)
+
+// hexDigitBitmaps holds one row of three bits per line, top to bottom.
+var hexDigitBitmaps = [16][hexDigitHeight]uint8{
+	{7, 5, 5, 5, 7}, // 0
+	{2, 6, 2, 2, 7}, // 1
+	{7, 1, 7, 4, 7}, // 2
+	{7, 1, 7, 1, 7}, // 3
+	{5, 5, 7, 1, 1}, // 4
+	{7, 4, 7, 1, 7}, // 5
+	{7, 4, 7, 5, 7}, // 6
+	{7, 1, 1, 1, 1}, // 7
+	{7, 5, 7, 5, 7}, // 8
+	{7, 5, 7, 1, 7}, // 9
+	{2, 5, 7, 5, 5}, // A
+	{6, 5, 6, 5, 6}, // B
+	{3, 4, 4, 4, 3}, // C
+	{6, 5, 5, 5, 6}, // D
+	{7, 4, 6, 4, 7}, // E
+	{7, 4, 6, 4, 4}, // F
+}
+
+// hexBoxUnit picks the digit pixel size for a font of the given pixel size.
+func hexBoxUnit(pixels int) int {
+	unit := (pixels + 8) / 16
+	if unit < 1 {
+		unit = 1
+	}
+	return unit
+}
+
+// fillRect sets the coverage of the rectangle, clipped to the mask.
+func fillRect(mask *image.Alpha, r image.Rectangle) {
+	r = r.Intersect(mask.Rect)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			mask.Pix[mask.PixOffset(x, y)] = 0xff
+		}
+	}
+}
+
+// drawHexBox draws the frame of a hex box with its top left corner at (x, y).
+func drawHexBox(mask *image.Alpha, x, y, unit int) {
+	w, h := hexBoxWidth*unit, hexBoxHeight*unit
+	fillRect(mask, image.Rect(x, y, x+w, y+unit))
+	fillRect(mask, image.Rect(x, y+h-unit, x+w, y+h))
+	fillRect(mask, image.Rect(x, y, x+unit, y+h))
+	fillRect(mask, image.Rect(x+w-unit, y, x+w, y+h))
+}
+
+// drawHexDigit draws a digit with its top left corner at (x, y).
+func drawHexDigit(mask *image.Alpha, digit, x, y, unit int) {
+	for row, bits := range hexDigitBitmaps[digit] {
+		for col := 0; col < hexDigitWidth; col++ {
+			if bits&(1<<uint(hexDigitWidth-1-col)) != 0 {
+				px, py := x+col*unit, y+row*unit
+				fillRect(mask, image.Rect(px, py, px+unit, py+unit))
+			}
+		}
+	}
+}
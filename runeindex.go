@@ -0,0 +1,76 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gltext
+
+import (
+	"sort"
+)
+
+// directLookupSize is the number of leading runes (ASCII and Latin-1) that are
+// resolved with a plain table rather than a search.
+const directLookupSize = 256
+
+type indexedRange struct {
+	RuneRange
+	offset rune // glyph index of Low
+}
+
+// A RuneIndex maps runes to glyph indices.  Low runes are found in a direct
+// lookup table and the rest by binary search over the sorted ranges, so a
+// lookup stays cheap even for hundreds of small ranges derived from real text.
+type RuneIndex struct {
+	source RuneRanges
+	ranges []indexedRange
+	direct [directLookupSize]int32
+}
+
+// NewRuneIndex precomputes the glyph offsets of each range.  The glyph
+// indices match those of RuneRanges.GetGlyphIndex, which follow the order of
+// the ranges in rr even when they are not sorted.
+func NewRuneIndex(rr RuneRanges) *RuneIndex {
+	ri := &RuneIndex{source: rr, ranges: make([]indexedRange, len(rr))}
+	offset := rune(0)
+	for i, r := range rr {
+		ri.ranges[i] = indexedRange{RuneRange: r, offset: offset}
+		offset += r.High - r.Low + 1
+	}
+	sort.Slice(ri.ranges, func(i, j int) bool { return ri.ranges[i].Low < ri.ranges[j].Low })
+
+	for i := range ri.direct {
+		ri.direct[i] = int32(ri.search(rune(i)))
+	}
+	return ri
+}
+
+func (ri *RuneIndex) search(char rune) rune {
+	i := sort.Search(len(ri.ranges), func(i int) bool { return ri.ranges[i].High >= char })
+	if i < len(ri.ranges) && ri.ranges[i].Low <= char {
+		return char - ri.ranges[i].Low + ri.ranges[i].offset
+	}
+	return -1
+}
+
+// GlyphIndex returns the location of the glyph within the sprite sheet, or -1
+// when the rune is not covered.
+func (ri *RuneIndex) GlyphIndex(char rune) rune {
+	if uint32(char) < directLookupSize {
+		return rune(ri.direct[char])
+	}
+	return ri.search(char)
+}
+
+// Contains returns true when the rune is covered.
+func (ri *RuneIndex) Contains(char rune) bool {
+	return ri.GlyphIndex(char) >= 0
+}
+
+// indexes reports whether the index was built from rr.  Replacing RuneRanges
+// is detected; ranges edited in place are not.
+func (ri *RuneIndex) indexes(rr RuneRanges) bool {
+	if len(ri.source) != len(rr) {
+		return false
+	}
+	return len(rr) == 0 || &ri.source[0] == &rr[0]
+}
@@ -0,0 +1,112 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gltext
+
+import (
+	"sort"
+)
+
+// RuneCount returns the number of runes covered by the ranges, which is also
+// the number of glyphs required to hold them.
+func (rr RuneRanges) RuneCount() int {
+	count := 0
+	for _, r := range rr {
+		count += int(r.High - r.Low + 1)
+	}
+	return count
+}
+
+// Contains returns true when the rune falls within one of the ranges.
+func (rr RuneRanges) Contains(char rune) bool {
+	for _, r := range rr {
+		if char >= r.Low && char <= r.High {
+			return true
+		}
+	}
+	return false
+}
+
+// Normalize returns a sorted copy of the ranges in which overlapping and
+// adjacent ranges have been merged.  Empty ranges (Low > High) are dropped.
+// The result always passes Validate.
+func (rr RuneRanges) Normalize() RuneRanges {
+	sorted := make(RuneRanges, 0, len(rr))
+	for _, r := range rr {
+		if r.Low <= r.High {
+			sorted = append(sorted, r)
+		}
+	}
+	sort.Sort(sorted)
+
+	normalized := make(RuneRanges, 0, len(sorted))
+	for _, r := range sorted {
+		last := len(normalized) - 1
+		if last >= 0 && r.Low <= normalized[last].High+1 {
+			if r.High > normalized[last].High {
+				normalized[last].High = r.High
+			}
+			continue
+		}
+		normalized = append(normalized, r)
+	}
+	return normalized
+}
+
+// Union returns the normalized set of runes found in either rr or other.
+func (rr RuneRanges) Union(other RuneRanges) RuneRanges {
+	union := make(RuneRanges, 0, len(rr)+len(other))
+	union = append(union, rr...)
+	union = append(union, other...)
+	return union.Normalize()
+}
+
+// Intersect returns the normalized set of runes found in both rr and other.
+func (rr RuneRanges) Intersect(other RuneRanges) RuneRanges {
+	a, b := rr.Normalize(), other.Normalize()
+	intersection := make(RuneRanges, 0)
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		low, high := a[i].Low, a[i].High
+		if b[j].Low > low {
+			low = b[j].Low
+		}
+		if b[j].High < high {
+			high = b[j].High
+		}
+		if low <= high {
+			intersection = append(intersection, RuneRange{Low: low, High: high})
+		}
+		// drop whichever range ends first, the other may still overlap the next one
+		if a[i].High < b[j].High {
+			i++
+		} else {
+			j++
+		}
+	}
+	return intersection
+}
+
+// Subtract returns the normalized set of runes found in rr but not in other.
+func (rr RuneRanges) Subtract(other RuneRanges) RuneRanges {
+	a, b := rr.Normalize(), other.Normalize()
+	difference := make(RuneRanges, 0, len(a))
+	j := 0
+	for _, r := range a {
+		// skip ranges that end before this one begins
+		for j < len(b) && b[j].High < r.Low {
+			j++
+		}
+		low := r.Low
+		for k := j; k < len(b) && b[k].Low <= r.High; k++ {
+			if b[k].Low > low {
+				difference = append(difference, RuneRange{Low: low, High: b[k].Low - 1})
+			}
+			low = b[k].High + 1
+		}
+		if low <= r.High {
+			difference = append(difference, RuneRange{Low: low, High: r.High})
+		}
+	}
+	return difference
+}
@@ -0,0 +1,125 @@
+package gltext
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRuneRangesNormalize(t *testing.T) {
+	rr := RuneRanges{{Low: 50, High: 60}, {Low: 0, High: 10}, {Low: 11, High: 20}, {Low: 55, High: 70}, {Low: 5, High: 4}}
+	expected := RuneRanges{{Low: 0, High: 20}, {Low: 50, High: 70}}
+	if n := rr.Normalize(); !reflect.DeepEqual(n, expected) {
+		t.Error("Unexpected normalization", n)
+	}
+	if !expected.Validate() {
+		t.Error("Ranges starting at 0 should validate.")
+	}
+	if (RuneRanges{{Low: 0, High: 10}, {Low: 10, High: 20}}).Validate() {
+		t.Error("Expecting invalidity due to overlap.")
+	}
+}
+
+func TestRuneRangesAlgebra(t *testing.T) {
+	a := RuneRanges{{Low: 10, High: 20}, {Low: 30, High: 40}}
+	b := RuneRanges{{Low: 15, High: 35}}
+
+	if u := a.Union(b); !reflect.DeepEqual(u, RuneRanges{{Low: 10, High: 40}}) {
+		t.Error("Unexpected union", u)
+	}
+	if i := a.Intersect(b); !reflect.DeepEqual(i, RuneRanges{{Low: 15, High: 20}, {Low: 30, High: 35}}) {
+		t.Error("Unexpected intersection", i)
+	}
+	if d := a.Subtract(b); !reflect.DeepEqual(d, RuneRanges{{Low: 10, High: 14}, {Low: 36, High: 40}}) {
+		t.Error("Unexpected difference", d)
+	}
+	if d := b.Subtract(a); !reflect.DeepEqual(d, RuneRanges{{Low: 21, High: 29}}) {
+		t.Error("Unexpected difference", d)
+	}
+	if !a.Contains(40) || a.Contains(25) {
+		t.Error("Contains is incorrect.")
+	}
+	if a.RuneCount() != 22 {
+		t.Error("Unexpected rune count", a.RuneCount())
+	}
+}
+
+func TestRuneIndex(t *testing.T) {
+	// unsorted ranges keep their glyph order
+	rr := RuneRanges{{Low: 0x4e00, High: 0x4e10}, {Low: 32, High: 127}, {Low: 300, High: 310}}
+	ri := NewRuneIndex(rr)
+	for _, char := range []rune{0, 31, 32, 127, 128, 299, 300, 310, 311, 0x4e00, 0x4e10, 0x4e11, -5} {
+		if got, expected := ri.GlyphIndex(char), rr.GetGlyphIndex(char); got != expected {
+			t.Errorf("%U: expecting %d got %d", char, expected, got)
+		}
+	}
+
+	fc := &FontConfig{}
+	fc.SetRuneRanges(rr)
+	if fc.GlyphIndex(0x4e05) != 5 || !fc.HasRune(' ') {
+		t.Error("Unexpected font config lookup")
+	}
+	fc.SetRuneRanges(RuneRanges{{Low: 'a', High: 'z'}})
+	if fc.GlyphIndex('b') != 1 || fc.HasRune(' ') {
+		t.Error("Replacing the ranges should rebuild the index")
+	}
+	fc.RuneRanges = RuneRanges{{Low: 'A', High: 'Z'}, {Low: 'a', High: 'z'}}
+	if fc.GlyphIndex('b') != 27 || fc.index.indexes(fc.RuneRanges) {
+		t.Error("Assigned ranges should be looked up without touching the index")
+	}
+	fc.RuneRanges[1].High = 'c'
+	fc.SetRuneRanges(fc.RuneRanges)
+	if fc.HasRune('d') {
+		t.Error("Ranges edited in place should be seen once they are set again")
+	}
+}
+
+var glyphIndexSink rune
+
+// fragmentedCJK returns hundreds of small ranges such as those derived from
+// localization files, along with a sample of runes to look up.
+func fragmentedCJK() (RuneRanges, []rune) {
+	rs := make(RuneSet)
+	rs.Add("The quick brown fox jumps over the lazy dog. 0123456789")
+	for r := rune(0x4e00); r < 0x9fa0; r += 7 + r%13 {
+		rs[r] = struct{}{}
+	}
+	rr := rs.RuneRanges()
+	sample := make([]rune, 0)
+	for _, r := range rr {
+		sample = append(sample, r.Low, r.High)
+	}
+	return rr, sample
+}
+
+func benchmarkGlyphIndex(b *testing.B, rr RuneRanges, sample []rune, indexed bool) {
+	fc := &FontConfig{}
+	fc.SetRuneRanges(rr)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, r := range sample {
+			if indexed {
+				glyphIndexSink = fc.GlyphIndex(r)
+			} else {
+				glyphIndexSink = rr.GetGlyphIndex(r)
+			}
+		}
+	}
+}
+
+func BenchmarkGlyphIndexASCII(b *testing.B) {
+	benchmarkGlyphIndex(b, RuneRanges{{Low: 32, High: 127}}, []rune("The quick brown fox jumps over the lazy dog."), true)
+}
+
+func BenchmarkGlyphIndexASCIILinear(b *testing.B) {
+	benchmarkGlyphIndex(b, RuneRanges{{Low: 32, High: 127}}, []rune("The quick brown fox jumps over the lazy dog."), false)
+}
+
+func BenchmarkGlyphIndexFragmentedCJK(b *testing.B) {
+	rr, sample := fragmentedCJK()
+	benchmarkGlyphIndex(b, rr, sample, true)
+}
+
+func BenchmarkGlyphIndexFragmentedCJKLinear(b *testing.B) {
+	rr, sample := fragmentedCJK()
+	benchmarkGlyphIndex(b, rr, sample, false)
+}
@@ -0,0 +1,157 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gltext
+
+import (
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"math"
+)
+
+// syntheticFace derives bold and oblique styles from a regular face.
+// Emboldened outlines grow by strength pixels to the right and upwards, so the
+// left side bearing and the baseline stay in place while advances widen by the
+// same amount.  Oblique outlines are sheared about a line pivot pixels above
+// the baseline rather than the baseline itself: glyph quads only span the
+// advance, so the slant is spread over both side bearings.  Their advances are
+// left alone.
+type syntheticFace struct {
+	bakeFace
+	strength fixed.Int26_6 // emboldening in 26.6 pixels
+	shear    float64       // horizontal shift per pixel of height
+	pivot    fixed.Int26_6 // height of the unsheared line in 26.6 pixels
+}
+
+func (f *syntheticFace) advance(index int) fixed.Int26_6 {
+	return f.bakeFace.advance(index) + f.strength
+}
+
+func (f *syntheticFace) bounds() fixed.Rectangle26_6 {
+	b := f.bakeFace.bounds()
+	b.Max.X += f.strength
+	b.Min.Y -= f.strength
+
+	// y increases downwards, so glyphs lean right for a positive shear
+	top, bottom := float64(b.Min.Y+f.pivot), float64(b.Max.Y+f.pivot)
+	left := math.Min(float64(b.Min.X)-f.shear*top, float64(b.Min.X)-f.shear*bottom)
+	right := math.Max(float64(b.Max.X)-f.shear*top, float64(b.Max.X)-f.shear*bottom)
+	b.Min.X, b.Max.X = fixed.Int26_6(math.Floor(left)), fixed.Int26_6(math.Ceil(right))
+	return b
+}
+
+func (f *syntheticFace) outline(index int) (sfnt.Segments, error) {
+	segments, err := f.bakeFace.outline(index)
+	if err != nil {
+		return nil, err
+	}
+	if f.strength > 0 {
+		embolden(segments, f.strength)
+	}
+	if f.shear != 0 {
+		for i := range segments {
+			for j := range segments[i].Args {
+				p := &segments[i].Args[j]
+				p.X -= fixed.Int26_6(f.shear * float64(p.Y+f.pivot))
+			}
+		}
+	}
+	return segments, nil
+}
+
+// embolden dilates the outline in place by strength, moving every point, off
+// curve control points included, along the bisector of its neighbouring edges
+// the way FreeType's FT_Outline_Embolden does.  The result is shifted so that
+// it grows to the right and upwards only.
+func embolden(segments sfnt.Segments, strength fixed.Int26_6) {
+	// outer contours and holes wind in opposite directions; the direction of
+	// the whole outline tells which side of an edge is outside
+	var area float64
+	for _, contour := range contours(segments) {
+		for i, p := range contour {
+			q := contour[(i+1)%len(contour)]
+			area += float64(p.X)*float64(q.Y) - float64(q.X)*float64(p.Y)
+		}
+	}
+	orientation := 1.0
+	if area < 0 {
+		orientation = -1
+	}
+	half := float64(strength) / 2
+
+	for _, points := range contourPoints(segments) {
+		n := len(points)
+		original := make([]fixed.Point26_6, n)
+		for i, p := range points {
+			original[i] = *p
+		}
+		for i := range points {
+			// the neighbours must differ from the point for the edges to have a direction
+			prev, next := -1, -1
+			for k := 1; k < n; k++ {
+				if j := (i - k + n) % n; prev < 0 && original[j] != original[i] {
+					prev = j
+				}
+				if j := (i + k) % n; next < 0 && original[j] != original[i] {
+					next = j
+				}
+			}
+			var dx, dy float64
+			if prev >= 0 && next >= 0 {
+				inX, inY := unit(original[i].X-original[prev].X, original[i].Y-original[prev].Y)
+				outX, outY := unit(original[next].X-original[i].X, original[next].Y-original[i].Y)
+				// outward normals of both edges
+				niX, niY := orientation*inY, -orientation*inX
+				noX, noY := orientation*outY, -orientation*outX
+				// the miter grows without bound as the edges fold back, sharp
+				// turns are not shifted at all
+				if d := 1 + niX*noX + niY*noY; d > 0.0625 {
+					dx, dy = (niX+noX)*half/d, (niY+noY)*half/d
+				}
+			}
+			points[i].X = original[i].X + fixed.Int26_6(math.Round(dx+half))
+			points[i].Y = original[i].Y + fixed.Int26_6(math.Round(dy-half))
+		}
+	}
+}
+
+func unit(x, y fixed.Int26_6) (float64, float64) {
+	l := math.Hypot(float64(x), float64(y))
+	return float64(x) / l, float64(y) / l
+}
+
+// contourPoints groups pointers to every point of the outline, control points
+// included, by contour.
+func contourPoints(segments sfnt.Segments) [][]*fixed.Point26_6 {
+	groups := make([][]*fixed.Point26_6, 0)
+	for i := range segments {
+		s := &segments[i]
+		if s.Op == sfnt.SegmentOpMoveTo || len(groups) == 0 {
+			groups = append(groups, make([]*fixed.Point26_6, 0))
+		}
+		last := len(groups) - 1
+		switch s.Op {
+		case sfnt.SegmentOpMoveTo, sfnt.SegmentOpLineTo:
+			groups[last] = append(groups[last], &s.Args[0])
+		case sfnt.SegmentOpQuadTo:
+			groups[last] = append(groups[last], &s.Args[0], &s.Args[1])
+		case sfnt.SegmentOpCubeTo:
+			groups[last] = append(groups[last], &s.Args[0], &s.Args[1], &s.Args[2])
+		}
+	}
+	return groups
+}
+
+// contours returns the points of every contour of the outline.
+func contours(segments sfnt.Segments) [][]fixed.Point26_6 {
+	groups := contourPoints(segments)
+	points := make([][]fixed.Point26_6, len(groups))
+	for i, group := range groups {
+		points[i] = make([]fixed.Point26_6, len(group))
+		for j, p := range group {
+			points[i][j] = *p
+		}
+	}
+	return points
+}
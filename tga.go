@@ -0,0 +1,260 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gltext
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"io"
+)
+
+// TGA files do not begin with a magic number.  The second and third bytes hold the
+// color map type and the image type, which is enough to tell them apart from the
+// other registered formats.
+func init() {
+	for _, magic := range []string{
+		"?\x00\x02", "?\x00\x03", "?\x00\x0a", "?\x00\x0b", // true color and grayscale
+		"?\x01\x01", "?\x01\x09", // color mapped
+	} {
+		image.RegisterFormat("tga", magic, decodeTGA, decodeTGAConfig)
+	}
+}
+
+const (
+	tgaColorMapped   = 1
+	tgaTrueColor     = 2
+	tgaGrayscale     = 3
+	tgaRLE           = 8
+	tgaRightToLeft   = 1 << 4
+	tgaTopToBottom   = 1 << 5
+	tgaAlphaBitsMask = 0x0f
+)
+
+type tgaHeader struct {
+	idLength       uint8
+	colorMapType   uint8
+	imageType      uint8
+	colorMapOrigin int
+	colorMapLength int
+	colorMapDepth  uint8
+	width          int
+	height         int
+	depth          uint8
+	descriptor     uint8
+}
+
+func readTGAHeader(r io.Reader) (h tgaHeader, err error) {
+	var b [18]byte
+	if _, err = io.ReadFull(r, b[:]); err != nil {
+		return h, err
+	}
+	h.idLength = b[0]
+	h.colorMapType = b[1]
+	h.imageType = b[2]
+	h.colorMapOrigin = int(b[3]) | int(b[4])<<8
+	h.colorMapLength = int(b[5]) | int(b[6])<<8
+	h.colorMapDepth = b[7]
+	h.width = int(b[12]) | int(b[13])<<8
+	h.height = int(b[14]) | int(b[15])<<8
+	h.depth = b[16]
+	h.descriptor = b[17]
+
+	switch h.imageType &^ tgaRLE {
+	case tgaColorMapped:
+		if h.colorMapType != 1 || h.depth != 8 {
+			return h, errors.New("tga: unsupported color mapped image")
+		}
+	case tgaTrueColor:
+		if h.depth != 15 && h.depth != 16 && h.depth != 24 && h.depth != 32 {
+			return h, errors.New("tga: unsupported true color depth")
+		}
+	case tgaGrayscale:
+		if h.depth != 8 && h.depth != 16 {
+			return h, errors.New("tga: unsupported grayscale depth")
+		}
+	default:
+		return h, errors.New("tga: unsupported image type")
+	}
+	return h, nil
+}
+
+func (h tgaHeader) colorModel() color.Model {
+	if h.imageType&^tgaRLE == tgaGrayscale && h.depth == 8 {
+		return color.GrayModel
+	}
+	return color.NRGBAModel
+}
+
+func decodeTGAConfig(r io.Reader) (image.Config, error) {
+	h, err := readTGAHeader(r)
+	if err != nil {
+		return image.Config{}, err
+	}
+	return image.Config{ColorModel: h.colorModel(), Width: h.width, Height: h.height}, nil
+}
+
+// tgaColor converts a little endian pixel of the given bit depth to a color.
+// Alpha is only honoured when the image descriptor says that alpha bits exist.
+func tgaColor(p []byte, depth uint8, grayscale, hasAlpha bool) color.NRGBA {
+	switch {
+	case grayscale && depth == 8:
+		return color.NRGBA{p[0], p[0], p[0], 0xff}
+	case grayscale:
+		a := p[1]
+		if !hasAlpha {
+			a = 0xff
+		}
+		return color.NRGBA{p[0], p[0], p[0], a}
+	case depth == 15 || depth == 16:
+		v := uint16(p[0]) | uint16(p[1])<<8
+		c := color.NRGBA{
+			R: uint8((v>>10)&0x1f) << 3,
+			G: uint8((v>>5)&0x1f) << 3,
+			B: uint8(v&0x1f) << 3,
+			A: 0xff,
+		}
+		if depth == 16 && hasAlpha && v&0x8000 == 0 {
+			c.A = 0
+		}
+		return c
+	case depth == 24:
+		return color.NRGBA{p[2], p[1], p[0], 0xff}
+	}
+	a := p[3]
+	if !hasAlpha {
+		a = 0xff
+	}
+	return color.NRGBA{p[2], p[1], p[0], a}
+}
+
+func decodeTGA(r io.Reader) (image.Image, error) {
+	br := bufio.NewReader(r)
+	h, err := readTGAHeader(br)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = br.Discard(int(h.idLength)); err != nil {
+		return nil, err
+	}
+
+	// the color map precedes the pixel data even when the image does not use it
+	var palette []color.NRGBA
+	if h.colorMapType == 1 {
+		entrySize := (int(h.colorMapDepth) + 7) / 8
+		if entrySize < 2 || entrySize > 4 {
+			return nil, errors.New("tga: unsupported color map depth")
+		}
+		entries := make([]byte, entrySize*h.colorMapLength)
+		if _, err = io.ReadFull(br, entries); err != nil {
+			return nil, err
+		}
+		palette = make([]color.NRGBA, h.colorMapOrigin+h.colorMapLength)
+		for i := 0; i < h.colorMapLength; i++ {
+			p := entries[i*entrySize : (i+1)*entrySize]
+			palette[h.colorMapOrigin+i] = tgaColor(p, h.colorMapDepth, false, h.descriptor&tgaAlphaBitsMask > 0)
+		}
+	}
+
+	grayscale := h.imageType&^tgaRLE == tgaGrayscale
+	hasAlpha := h.descriptor&tgaAlphaBitsMask > 0
+	pixelSize := (int(h.depth) + 7) / 8
+	// the header may claim up to 65535x65535 pixels, so the pixel data only grows
+	// with the input actually read rather than being allocated up front
+	var pixels []byte
+	if h.imageType&tgaRLE == 0 {
+		pixels, err = readTGARaw(br, h.width*h.height*pixelSize)
+	} else {
+		pixels, err = readTGARLE(br, h.width*h.height*pixelSize, pixelSize)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rect := image.Rect(0, 0, h.width, h.height)
+	var gray *image.Gray
+	var nrgba *image.NRGBA
+	if h.colorModel() == color.GrayModel {
+		gray = image.NewGray(rect)
+	} else {
+		nrgba = image.NewNRGBA(rect)
+	}
+	for i := 0; i < h.width*h.height; i++ {
+		x, y := i%h.width, i/h.width
+		if h.descriptor&tgaRightToLeft != 0 {
+			x = h.width - 1 - x
+		}
+		if h.descriptor&tgaTopToBottom == 0 {
+			y = h.height - 1 - y
+		}
+		p := pixels[i*pixelSize : (i+1)*pixelSize]
+		if gray != nil {
+			gray.Pix[gray.PixOffset(x, y)] = p[0]
+			continue
+		}
+		var c color.NRGBA
+		if palette != nil {
+			if int(p[0]) >= len(palette) {
+				return nil, errors.New("tga: color map index out of range")
+			}
+			c = palette[p[0]]
+		} else {
+			c = tgaColor(p, h.depth, grayscale, hasAlpha)
+		}
+		nrgba.SetNRGBA(x, y, c)
+	}
+	if gray != nil {
+		return gray, nil
+	}
+	return nrgba, nil
+}
+
+// readTGARaw reads size bytes of uncompressed pixels.
+func readTGARaw(br *bufio.Reader, size int) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, br, int64(size)); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// readTGARLE expands run length encoded packets into size bytes of pixels.
+func readTGARLE(br *bufio.Reader, size, pixelSize int) ([]byte, error) {
+	pixels := make([]byte, 0, 4096)
+	pixel := make([]byte, pixelSize)
+	for len(pixels) < size {
+		packet, err := br.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		count := int(packet&0x7f) + 1
+		if len(pixels)+count*pixelSize > size {
+			return nil, errors.New("tga: run length packet exceeds image size")
+		}
+		if packet&0x80 == 0 {
+			// raw packet
+			for i := 0; i < count; i++ {
+				if _, err = io.ReadFull(br, pixel); err != nil {
+					return nil, err
+				}
+				pixels = append(pixels, pixel...)
+			}
+			continue
+		}
+		// run-length packet
+		if _, err = io.ReadFull(br, pixel); err != nil {
+			return nil, err
+		}
+		for i := 0; i < count; i++ {
+			pixels = append(pixels, pixel...)
+		}
+	}
+	return pixels, nil
+}
@@ -0,0 +1,100 @@
+package gltext
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func tgaFile(imageType, depth, descriptor byte, pixels ...byte) []byte {
+	header := []byte{0, 0, imageType, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2, 0, 2, 0, depth, descriptor}
+	return append(header, pixels...)
+}
+
+func TestDecodeTGA(t *testing.T) {
+	// bottom-up 2x2 BGRA: the first row in the file is the bottom row of the image
+	raw := tgaFile(2, 32, 8,
+		0, 0, 255, 255, 0, 255, 0, 255,
+		255, 0, 0, 255, 0, 0, 0, 0,
+	)
+	// the same pixels using two raw packets
+	rle := tgaFile(10, 32, 8,
+		0x01, 0, 0, 255, 255, 0, 255, 0, 255,
+		0x01, 255, 0, 0, 255, 0, 0, 0, 0,
+	)
+	for _, data := range [][]byte{raw, rle} {
+		img, format, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if format != "tga" {
+			t.Error("Unexpected format", format)
+		}
+		expected := map[image.Point]color.NRGBA{
+			{0, 1}: {255, 0, 0, 255},
+			{1, 1}: {0, 255, 0, 255},
+			{0, 0}: {0, 0, 255, 255},
+			{1, 0}: {0, 0, 0, 0},
+		}
+		for p, c := range expected {
+			if got := img.(*image.NRGBA).NRGBAAt(p.X, p.Y); got != c {
+				t.Errorf("%v: expecting %v got %v", p, c, got)
+			}
+		}
+	}
+}
+
+func TestDecodeTGARunLength(t *testing.T) {
+	// a run of three red pixels followed by a raw green one
+	data := tgaFile(10, 24, 0x20,
+		0x82, 0, 0, 255,
+		0x00, 0, 255, 0,
+	)
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[image.Point]color.NRGBA{
+		{0, 0}: {255, 0, 0, 255},
+		{1, 0}: {255, 0, 0, 255},
+		{0, 1}: {255, 0, 0, 255},
+		{1, 1}: {0, 255, 0, 255},
+	}
+	for p, c := range expected {
+		if got := img.(*image.NRGBA).NRGBAAt(p.X, p.Y); got != c {
+			t.Errorf("%v: expecting %v got %v", p, c, got)
+		}
+	}
+
+	// runs may not reach beyond the image
+	if _, _, err := image.Decode(bytes.NewReader(tgaFile(10, 24, 0x20, 0x84, 0, 0, 255))); err == nil {
+		t.Error("Expecting an error for a run beyond the image")
+	}
+}
+
+func TestDecodeTGATruncated(t *testing.T) {
+	// a header claiming 65535x65535 pixels followed by a single pixel
+	for _, imageType := range []byte{2, 10} {
+		data := tgaFile(imageType, 32, 8, 0, 0, 0, 0, 0)
+		data[12], data[13], data[14], data[15] = 0xff, 0xff, 0xff, 0xff
+		if _, _, err := image.Decode(bytes.NewReader(data)); err == nil {
+			t.Error("Expecting an error for truncated pixel data", imageType)
+		}
+	}
+}
+
+func TestDecodeTGAGrayscale(t *testing.T) {
+	data := tgaFile(3, 8, 0x20, 1, 2, 3, 4)
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gray, ok := img.(*image.Gray)
+	if !ok {
+		t.Fatalf("Expecting a grayscale image, got %T", img)
+	}
+	if !bytes.Equal(gray.Pix, []byte{1, 2, 3, 4}) {
+		t.Error("Top-down rows should not be flipped", gray.Pix)
+	}
+}
@@ -6,11 +6,9 @@ package gltext
 
 import (
 	"errors"
-	"github.com/golang/freetype"
-	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
 	"golang.org/x/image/math/fixed"
 	"image"
-	"image/draw"
 	"io"
 	"io/ioutil"
 	"sort"
@@ -29,17 +27,20 @@ func (rr RuneRanges) Len() int           { return len(rr) }
 func (rr RuneRanges) Swap(i, j int)      { rr[i], rr[j] = rr[j], rr[i] }
 func (rr RuneRanges) Less(i, j int) bool { return rr[i].Low < rr[j].Low }
 
+// Validate returns true when every range has Low <= High and no two ranges
+// overlap.  The order of the ranges is not changed; use Normalize to merge
+// overlapping ranges instead of rejecting them.
 func (rr RuneRanges) Validate() bool {
-	sort.Sort(rr)
-	previousMax := rune(0)
-	for _, r := range rr {
-		if r.Low <= previousMax {
+	sorted := make(RuneRanges, len(rr))
+	copy(sorted, rr)
+	sort.Sort(sorted)
+	for i, r := range sorted {
+		if i > 0 && r.Low <= sorted[i-1].High {
 			return false
 		}
 		if r.Low > r.High {
 			return false
 		}
-		previousMax = r.High
 	}
 	return true
 }
@@ -48,26 +49,94 @@ func (rr RuneRanges) Validate() bool {
 // the compressed rune ranges covered by the font
 // EG if runes 0-25, 100-110 are supported by the font then
 // the actual location of 100 will be in position 26 in the png image
+//
+// The ranges are walked linearly; FontConfig.GlyphIndex and RuneIndex
+// provide the same result in logarithmic time.
 func (rr RuneRanges) GetGlyphIndex(char rune) rune {
-	var index, offset rune
-	index = -1
+	var offset rune
 	for _, runes := range rr {
 		if char >= runes.Low && char <= runes.High {
-			index = char - runes.Low + offset
+			return char - runes.Low + offset
 		}
 		offset += runes.High - runes.Low + 1
 	}
-	return index
+	return -1
+}
+
+// BakeOptions control how glyphs are rasterized into the sprite sheet.
+// The zero value matches the behaviour of NewTruetypeFontConfig.
+type BakeOptions struct {
+	// Format of the resulting sprite sheet.  The alpha and gray formats use a
+	// quarter of the memory required by the default NRGBA format.
+	Format ImageFormat
+
+	// SkipMissing leaves out runes that the font maps to the .notdef glyph so
+	// that they do not take up space in the sprite sheet.  The resulting
+	// RuneRanges only hold the runes that the font provides.
+	SkipMissing bool
+
+	// Replacement selects the glyph drawn for runes that are not part of the
+	// font config.  The font's .notdef glyph is used by default.
+	Replacement ReplacementStyle
+
+	// FaceIndex selects the face of a font collection (.ttc, .otc).  See
+	// FontFaces for the faces a file holds.
+	FaceIndex int
+
+	// DPI of the target display.  The font scale is given in points, so at the
+	// default of 72 a point is a pixel; 144 bakes twice as many pixels for a
+	// HiDPI display.
+	DPI float64
+
+	// Hinting fits TrueType outlines to the pixel grid.  Vertical hinting only
+	// moves points vertically and keeps the horizontal shape and advances.
+	// The outlines of CFF fonts and collections are never hinted.
+	Hinting font.Hinting
+
+	// Supersample rasterizes every glyph at this many samples per pixel along
+	// each axis and averages them.  Zero and one rasterize directly.
+	Supersample int
+
+	// Padding surrounds every glyph of the sprite sheet with this many
+	// transparent pixels so that filtering does not pick up its neighbours.
+	// Texture coordinates of padded fonts are inset by half a texel.
+	Padding int
+
+	// Extrude fills the padding with copies of each glyph's edge pixels,
+	// which keeps the edges intact at the smaller mipmap levels.
+	Extrude bool
+
+	// Embolden synthesizes a bold style by widening every stroke by this
+	// fraction of the font size.  0.04 is close to FreeType's synthetic bold.
+	// Advances grow by the same amount.
+	Embolden float64
+
+	// Oblique synthesizes an italic style by slanting the glyphs, shifting
+	// them right by this many pixels per pixel of height.  0.2 slants by about
+	// 11 degrees.
+	Oblique float64
+
+	// Gamma adjusts glyph coverage.  Values above one thicken and darken
+	// strokes, values below one thin them.  Zero leaves coverage unchanged.
+	Gamma float64
 }
 
 // http://www.freetype.org/freetype2/docs/tutorial/step2.html
 
 // LoadTruetype loads a truetype font from the given stream and
-// applies the given font scale in points.
+// applies the given font scale in points, which are pixels at 72 DPI.
+// OpenType fonts with CFF outlines and font collections are read as well, the
+// first face of a collection is used.
 //
 // The low and high values determine the lower and upper rune limits
 // we should load for this font. For standard ASCII this would be: 32, 127.
 func NewTruetypeFontConfig(r io.Reader, scale fixed.Int26_6, runeRanges RuneRanges, runesPerRow, adjustHeight fixed.Int26_6) (*FontConfig, error) {
+	return NewTruetypeFontConfigWithOptions(r, scale, runeRanges, runesPerRow, adjustHeight, BakeOptions{})
+}
+
+// NewTruetypeFontConfigWithOptions behaves like NewTruetypeFontConfig while
+// allowing the rasterization to be adjusted.
+func NewTruetypeFontConfigWithOptions(r io.Reader, scale fixed.Int26_6, runeRanges RuneRanges, runesPerRow, adjustHeight fixed.Int26_6, opts BakeOptions) (*FontConfig, error) {
 	if !runeRanges.Validate() {
 		return nil, errors.New("Invalid rune ranges supplied.")
 	}
@@ -77,81 +146,180 @@ func NewTruetypeFontConfig(r io.Reader, scale fixed.Int26_6, runeRanges RuneRang
 		return nil, err
 	}
 
-	// Read the truetype font.
-	ttf, err := truetype.Parse(data)
+	dpi := opts.DPI
+	if dpi <= 0 {
+		dpi = 72
+	}
+	ppem := fixed.Int26_6(float64(scale)*dpi/72*64 + 0.5)
+	if ppem <= 0 {
+		return nil, errors.New("The font scale must be positive.")
+	}
+	if opts.Padding < 0 {
+		return nil, errors.New("The padding must not be negative.")
+	}
+
+	// Read the font, or the chosen face of a collection.
+	face, err := parseFace(data, opts.FaceIndex, ppem, opts.Hinting)
 	if err != nil {
 		return nil, err
 	}
+	if opts.Embolden < 0 {
+		return nil, errors.New("The emboldening strength must not be negative.")
+	}
+	if opts.Embolden > 0 || opts.Oblique != 0 {
+		strength := fixed.Int26_6(opts.Embolden*float64(ppem) + 0.5)
+		face = &syntheticFace{bakeFace: face, strength: strength, shear: opts.Oblique, pivot: ppem / 3}
+	}
 
 	// Create our FontConfig type.
+	// The ranges are stored sorted so that glyphs are laid out in rune order.
 	fc := &FontConfig{}
-	length := rune(0)
-	for _, r := range runeRanges {
-		length += r.High - r.Low + 1
+	fc.SetRuneRanges(runeRanges.Normalize())
+	if opts.SkipMissing {
+		fc.SetRuneRanges(fontCoverage(face, fc.RuneRanges).Present)
+		if len(fc.RuneRanges) == 0 {
+			return nil, errors.New("The font does not contain any of the requested runes.")
+		}
 	}
-	fc.RuneRanges = runeRanges
-	fc.Glyphs = make(Charset, int(length))
+	fc.Glyphs = make(Charset, fc.RuneRanges.RuneCount())
 
 	// Create an image, large enough to store all requested glyphs.
 	// The resulting image is set to power of 2 dimensions so it might be wise to adjust the runesPerRow
 	// parameter to ensure that unnecessary space isn't created based on the character set being used
-	gc := fixed.Int26_6(len(fc.Glyphs))
+	// The runes are followed by the replacement glyph and, for hex boxes, the sixteen digits.
+	extraCells := 1
+	switch opts.Replacement {
+	case ReplacementNone:
+		extraCells = 0
+	case ReplacementHexBox:
+		extraCells = 1 + len(hexDigitBitmaps)
+	}
+	gc := fixed.Int26_6(len(fc.Glyphs) + extraCells)
 	runesPerCol := (gc / runesPerRow) + 1
 
-	gb := ttf.Bounds(scale)
-	gw := (gb.Max.X - gb.Min.X)
-	gh := (gb.Max.Y - gb.Min.Y) + adjustHeight
+	gb := face.bounds()
+	gw := fixed.Int26_6(gb.Max.X.Ceil() - gb.Min.X.Floor())
+	gh := fixed.Int26_6(gb.Max.Y.Ceil()-gb.Min.Y.Floor()) + adjustHeight
+
+	// each glyph occupies a cell holding the glyph and its padding
+	pad := fixed.Int26_6(opts.Padding)
+	cellW, cellH := gw+2*pad, gh+2*pad
+	fc.Padding = opts.Padding
 
-	iw := Pow2(uint32(gw * runesPerRow))
-	ih := Pow2(uint32(gh * runesPerCol))
+	iw := Pow2(uint32(cellW * runesPerRow))
+	ih := Pow2(uint32(cellH * runesPerCol))
 	if iw > ih {
 		ih = iw
 	} else {
 		iw = ih
 	}
-	fg, bg := image.White, image.Transparent
+	// Glyphs are drawn on a baseline this far below the top of their cell, so
+	// that the tallest glyph of the font touches the top of the cell and the
+	// deepest descender its bottom.
+	baseline := -gb.Min.Y.Floor()
+	fc.Baseline = baseline
+
+	// Glyph coverage is drawn into an alpha mask which is converted to the
+	// requested format once every glyph is in place.
 	rect := image.Rect(0, 0, int(iw), int(ih))
-	fc.Image = image.NewNRGBA(rect)
-	draw.Draw(fc.Image, fc.Image.Bounds(), bg, image.ZP, draw.Src)
-
-	// Use a freetype context to do the drawing.
-	c := freetype.NewContext()
-	c.SetDPI(72) // Do not change this.  It is required in order to have a properly aligned bounding box!!!
-	c.SetFont(ttf)
-	c.SetFontSize(float64(scale))
-	c.SetClip(fc.Image.Bounds())
-	c.SetDst(fc.Image)
-	c.SetSrc(fg)
+	mask := image.NewAlpha(rect)
+	raster := &rasterizer{samples: opts.Supersample}
+
+	// drawGlyph rasterizes the face's glyph with its origin on the baseline of g.
+	// Whatever lies outside of g, such as ink left of the origin, is cut off so
+	// that neighbouring glyphs and the padding stay clean.
+	drawGlyph := func(g Glyph, index int) error {
+		segments, err := face.outline(index)
+		if err != nil {
+			return err
+		}
+		clip := image.Rect(g.X, g.Y, g.X+g.Width, g.Y+g.Height)
+		raster.draw(mask, clip, segments, g.X, g.Y+baseline)
+		return nil
+	}
 
 	// Iterate over all relevant glyphs in the truetype font and draw them all to the image buffer
 	// Add Glyph objects to track various glyph values
 	var gi fixed.Int26_6
 	var gx, gy fixed.Int26_6
 
+	// nextCell returns a glyph positioned within the next free cell of the image
+	cells := make([]image.Rectangle, 0, int(gc))
+	nextCell := func() Glyph {
+		if gi%runesPerRow == 0 {
+			gx = 0
+			if gi > 0 {
+				gy += cellH
+			}
+		} else {
+			gx += cellW
+		}
+		gi++
+		g := Glyph{X: int(gx + pad), Y: int(gy + pad), Width: int(gw), Height: int(gh)}
+		cells = append(cells, image.Rect(g.X, g.Y, g.X+g.Width, g.Y+g.Height))
+		return g
+	}
+
+	i := 0
 	for _, runeRange := range fc.RuneRanges {
 		for ch := runeRange.Low; ch <= runeRange.High; ch++ {
-			index := ttf.Index(ch)
-			metric := ttf.HMetric(scale, index)
-
-			if gi%runesPerRow == 0 {
-				gx = 0
-				if gi > 0 {
-					gy += gh
-				}
-			} else {
-				gx += gw
+			index := face.index(ch)
+
+			fc.Glyphs[i] = nextCell()
+			fc.Glyphs[i].Advance = face.advance(index).Round()
+			if err := drawGlyph(fc.Glyphs[i], index); err != nil {
+				return nil, err
 			}
-			fc.Glyphs[gi].Advance = int(metric.AdvanceWidth)
-			fc.Glyphs[gi].X = int(gx)
-			fc.Glyphs[gi].Y = int(gy)
-			fc.Glyphs[gi].Width = int(gw)
-			fc.Glyphs[gi].Height = int(gh)
-
-			pt := freetype.Pt(int(gx), int(gy)+int(c.PointToFixed(float64(scale))>>6))
-			c.DrawString(string(ch), pt)
-			gi++
+			i++
 		}
 	}
+
+	switch opts.Replacement {
+	case ReplacementNotdef, ReplacementCharacter:
+		index := 0 // .notdef
+		if opts.Replacement == ReplacementCharacter {
+			index = face.index(0xfffd)
+		}
+		g := nextCell()
+		g.Advance = face.advance(index).Round()
+		if err := drawGlyph(g, index); err != nil {
+			return nil, err
+		}
+		fc.Replacement = &g
+	case ReplacementHexBox:
+		// the box sits on the baseline unless it would not fit in the cell
+		unit := hexBoxUnit(ppem.Round())
+		top := baseline - hexBoxHeight*unit
+		if top < 0 {
+			top = 0
+		}
+		g := nextCell()
+		g.Advance = (hexBoxWidth + 1) * unit
+		if g.Advance > g.Width {
+			g.Advance = g.Width
+		}
+		drawHexBox(mask, g.X, g.Y+top, unit)
+
+		hb := &HexBox{Top: top, Unit: unit, Digits: make(Charset, len(hexDigitBitmaps))}
+		for digit := range hb.Digits {
+			dg := nextCell()
+			dg.Width, dg.Height = hexDigitWidth*unit, hexDigitHeight*unit
+			dg.Advance = dg.Width
+			drawHexDigit(mask, digit, dg.X, dg.Y, unit)
+			hb.Digits[digit] = dg
+		}
+		fc.Replacement, fc.HexBox = &g, hb
+	}
+
+	adjustGamma(mask, opts.Gamma)
+	if opts.Extrude {
+		for _, cell := range cells {
+			extrude(mask, cell, opts.Padding)
+		}
+	}
+
+	fc.Format = opts.Format
+	fc.Image = ConvertImage(mask, opts.Format)
 	return fc, nil
 }
 
@@ -1,7 +1,11 @@
 package gltext
 
 import (
+	"bytes"
+	"golang.org/x/image/font"
 	"golang.org/x/image/math/fixed"
+	"image"
+	"io/ioutil"
 	"os"
 	"testing"
 )
@@ -16,9 +20,12 @@ func TestRuneRangesSort(t *testing.T) {
 	if !rr.Validate() {
 		t.Error("Not validating.")
 	}
+	if rr[0].Low != 400 {
+		t.Error("Validate should not reorder the ranges.")
+	}
 
 	previousMax := rune(0)
-	for _, r := range rr {
+	for _, r := range rr.Normalize() {
 		if r.Low < previousMax {
 			t.Error("Unsorted")
 		}
@@ -119,3 +126,286 @@ func TestGetGlyphIndexEdge(t *testing.T) {
 		panic(err)
 	}
 }
+
+func TestAlphaFormatRoundTrip(t *testing.T) {
+	fd, err := os.Open("example/font/luxisr.ttf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+
+	runeRanges := RuneRanges{{Low: 32, High: 127}}
+	opts := BakeOptions{Format: ImageFormatAlpha}
+	config, err := NewTruetypeFontConfigWithOptions(fd, fixed.Int26_6(24), runeRanges, fixed.Int26_6(16), 0, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	baked, ok := config.Image.(*image.Alpha)
+	if !ok {
+		t.Fatalf("Expecting an alpha image, got %T", config.Image)
+	}
+
+	dir := t.TempDir()
+	if err = config.Save(dir, "luxisr"); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadTruetypeFontConfig(dir, "luxisr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Format != ImageFormatAlpha {
+		t.Error("Format not preserved", loaded.Format)
+	}
+	img, ok := loaded.Image.(*image.Alpha)
+	if !ok {
+		t.Fatalf("Expecting an alpha image, got %T", loaded.Image)
+	}
+	if !bytes.Equal(img.Pix, baked.Pix) {
+		t.Error("Coverage differs after loading.")
+	}
+}
+
+func TestFontCoverage(t *testing.T) {
+	fd, err := os.Open("example/font/luxisr.ttf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+
+	runeRanges := RuneRanges{{Low: 'A', High: 'Z'}, {Low: 0x3041, High: 0x3096}}
+	coverage, err := FontCoverage(fd, runeRanges)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(coverage.Ranges) != 2 {
+		t.Fatal("Expecting a report per range", coverage.Ranges)
+	}
+	if p := coverage.Ranges[0].Percent(); p != 100 {
+		t.Error("Latin should be fully covered", p)
+	}
+	if p := coverage.Ranges[1].Percent(); p != 0 {
+		t.Error("Hiragana should be missing", p)
+	}
+	if !coverage.Present.Contains('Q') || !coverage.Missing.Contains('あ') {
+		t.Error("Unexpected present or missing runes")
+	}
+
+	fd.Seek(0, 0)
+	opts := BakeOptions{SkipMissing: true}
+	config, err := NewTruetypeFontConfigWithOptions(fd, fixed.Int26_6(16), runeRanges, fixed.Int26_6(16), 0, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Glyphs) != 26 || config.RuneRanges.Contains('あ') {
+		t.Error("Missing runes should not be baked", len(config.Glyphs))
+	}
+}
+
+func TestReplacementStyles(t *testing.T) {
+	fd, err := os.Open("example/font/luxisr.ttf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+
+	runeRanges := RuneRanges{{Low: 'A', High: 'Z'}}
+	for _, style := range []ReplacementStyle{ReplacementNotdef, ReplacementCharacter, ReplacementHexBox, ReplacementNone} {
+		fd.Seek(0, 0)
+		opts := BakeOptions{Replacement: style}
+		config, err := NewTruetypeFontConfigWithOptions(fd, fixed.Int26_6(32), runeRanges, fixed.Int26_6(8), 0, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (config.Replacement == nil) != (style == ReplacementNone) {
+			t.Error("Unexpected replacement glyph", style, config.Replacement)
+		}
+		if (config.HexBox != nil) != (style == ReplacementHexBox) {
+			t.Error("Unexpected hex box", style)
+		}
+	}
+
+	hb := &HexBox{Unit: 2, Digits: make(Charset, 16)}
+	for i := range hb.Digits {
+		hb.Digits[i].X = i
+	}
+	digits := hb.Layout(0x1f600)
+	if len(digits) != 6 || digits[1].Glyph.X != 1 || digits[2].Glyph.X != 0xf || digits[3].Glyph.X != 6 {
+		t.Error("Unexpected digits", digits)
+	}
+	if digits[3].X != 2*2 || digits[3].Y != 2*2+6*2 {
+		t.Error("Unexpected digit position", digits[3])
+	}
+}
+
+func TestBakeQuality(t *testing.T) {
+	data, err := ioutil.ReadFile("example/font/luxisr.ttf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bake := func(scale fixed.Int26_6, opts BakeOptions) *FontConfig {
+		opts.Format = ImageFormatAlpha
+		config, err := NewTruetypeFontConfigWithOptions(bytes.NewReader(data), scale, RuneRanges{{Low: 32, High: 127}}, 16, 0, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return config
+	}
+	coverage := func(config *FontConfig) (sum int) {
+		for _, a := range config.Image.(*image.Alpha).Pix {
+			sum += int(a)
+		}
+		return sum
+	}
+
+	// 18 points at 96 DPI are 24 pixels
+	pixels, points := bake(24, BakeOptions{}), bake(18, BakeOptions{DPI: 96})
+	if points.Baseline != pixels.Baseline || !bytes.Equal(points.Image.(*image.Alpha).Pix, pixels.Image.(*image.Alpha).Pix) {
+		t.Error("Baking in points at 96 DPI differs from baking in pixels.")
+	}
+	for i, g := range pixels.Glyphs {
+		if points.Glyphs[i] != g {
+			t.Fatalf("Glyph %d is %+v, expected %+v.", i, points.Glyphs[i], g)
+		}
+	}
+	hidpi := bake(18, BakeOptions{DPI: 192})
+	if g, h := pixels.Glyphs['M'-32], hidpi.Glyphs['M'-32]; h.Height < 2*g.Height-2 || h.Advance < 2*g.Advance-1 {
+		t.Errorf("Glyph %+v at 192 DPI should be twice the size of %+v.", h, g)
+	}
+
+	// every glyph must fit within its cell
+	descender := pixels.Glyphs['g'-32]
+	if descender.Height-pixels.Baseline < 24/8 {
+		t.Error("The cell leaves no room for descenders.", descender, pixels.Baseline)
+	}
+
+	// supersampling only changes the antialiasing, not the amount of ink
+	plain, supersampled := coverage(pixels), coverage(bake(24, BakeOptions{Supersample: 4}))
+	if diff := plain - supersampled; diff < -plain/50 || diff > plain/50 {
+		t.Errorf("Supersampled coverage %d differs too much from %d.", supersampled, plain)
+	}
+	if bold := coverage(bake(24, BakeOptions{Gamma: 2})); bold <= plain {
+		t.Errorf("A gamma of 2 should add coverage, %d <= %d.", bold, plain)
+	}
+	for _, hinting := range []font.Hinting{font.HintingVertical, font.HintingFull} {
+		hinted := bake(24, BakeOptions{Hinting: hinting})
+		if coverage(hinted) == 0 {
+			t.Error("Hinted glyphs are empty.", hinting)
+		}
+		if hinting == font.HintingVertical && hinted.Glyphs['M'-32].Advance != pixels.Glyphs['M'-32].Advance {
+			t.Error("Vertical hinting should keep the advances.")
+		}
+	}
+}
+
+func TestGlyphPadding(t *testing.T) {
+	data, err := ioutil.ReadFile("example/font/luxisr.ttf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	runeRanges := RuneRanges{{Low: 32, High: 127}}
+	for _, extrude := range []bool{false, true} {
+		opts := BakeOptions{Format: ImageFormatAlpha, Padding: 2, Extrude: extrude}
+		config, err := NewTruetypeFontConfigWithOptions(bytes.NewReader(data), 24, runeRanges, 16, 0, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if config.Padding != 2 || config.TextureInset() != 0.5 {
+			t.Error("Unexpected padding", config.Padding, config.TextureInset())
+		}
+		mask := config.Image.(*image.Alpha)
+		glyphs := append(config.Glyphs, *config.Replacement)
+		for i, g := range glyphs {
+			r := image.Rect(g.X, g.Y, g.X+g.Width, g.Y+g.Height)
+			for j, other := range glyphs[:i] {
+				if r.Inset(-2).Overlaps(image.Rect(other.X, other.Y, other.X+other.Width, other.Y+other.Height)) {
+					t.Fatalf("The padding of glyph %d overlaps glyph %d.", i, j)
+				}
+			}
+			// the ring around the glyph is either empty or a copy of its edge
+			for x := r.Min.X - 2; x < r.Max.X+2; x++ {
+				for _, y := range []int{r.Min.Y - 1, r.Max.Y + 1} {
+					want := uint8(0)
+					if extrude {
+						edgeX := clampInt(x, r.Min.X, r.Max.X-1)
+						want = mask.AlphaAt(edgeX, clampInt(y, r.Min.Y, r.Max.Y-1)).A
+					}
+					if got := mask.AlphaAt(x, y).A; got != want {
+						t.Fatalf("Glyph %d has coverage %d at (%d, %d), expected %d.", i, got, x, y, want)
+					}
+				}
+			}
+		}
+	}
+
+	g := Glyph{X: 10, Y: 20, Width: 8, Height: 16, Advance: 6}
+	tP1, tP2 := g.GetTexturePositionsInset(testFont{64, 64}, 0.5)
+	if tP1.X != 10.5/64 || tP1.Y != 20.5/64 || tP2.X != 15.5/64 || tP2.Y != 35.5/64 {
+		t.Error("Unexpected texture positions", tP1, tP2)
+	}
+}
+
+type testFont struct{ w, h float32 }
+
+func (f testFont) GetTextureWidth() float32  { return f.w }
+func (f testFont) GetTextureHeight() float32 { return f.h }
+
+func TestSyntheticStyles(t *testing.T) {
+	data, err := ioutil.ReadFile("example/font/luxisr.ttf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bake := func(opts BakeOptions) *FontConfig {
+		opts.Format = ImageFormatAlpha
+		config, err := NewTruetypeFontConfigWithOptions(bytes.NewReader(data), 32, RuneRanges{{Low: 'A', High: 'z'}}, 16, 0, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return config
+	}
+	// ink returns the coverage of a glyph and its horizontal centre of mass in
+	// the upper and lower half of its cell
+	ink := func(config *FontConfig, char rune) (sum int, upper, lower float64) {
+		g := config.Glyphs[config.GlyphIndex(char)]
+		mask := config.Image.(*image.Alpha)
+		var upperSum, lowerSum, upperX, lowerX float64
+		for y := g.Y; y < g.Y+g.Height; y++ {
+			for x := g.X; x < g.X+g.Width; x++ {
+				a := float64(mask.AlphaAt(x, y).A)
+				sum += int(a)
+				if y < g.Y+config.Baseline/2 {
+					upperSum, upperX = upperSum+a, upperX+a*float64(x)
+				} else if y < g.Y+config.Baseline {
+					lowerSum, lowerX = lowerSum+a, lowerX+a*float64(x)
+				}
+			}
+		}
+		return sum, upperX / upperSum, lowerX / lowerSum
+	}
+
+	regular, bold, oblique := bake(BakeOptions{}), bake(BakeOptions{Embolden: 0.05}), bake(BakeOptions{Oblique: 0.25})
+	for _, char := range "HMlo" {
+		r, b := regular.Glyphs[regular.GlyphIndex(char)], bold.Glyphs[bold.GlyphIndex(char)]
+		if d := b.Advance - r.Advance; d < 1 || d > 2 {
+			t.Errorf("Bold %q advances %d further, expected 1.6.", char, d)
+		}
+		regularInk, _, _ := ink(regular, char)
+		boldInk, _, _ := ink(bold, char)
+		if boldInk < regularInk*11/10 {
+			t.Errorf("Bold %q has %d coverage, regular %d.", char, boldInk, regularInk)
+		}
+		if o := oblique.Glyphs[oblique.GlyphIndex(char)]; o.Advance != r.Advance {
+			t.Errorf("Oblique %q advances %d, expected %d.", char, o.Advance, r.Advance)
+		}
+		_, upper, lower := ink(oblique, char)
+		if upper-lower < 2 {
+			t.Errorf("Oblique %q should lean right: upper half at %.1f, lower half at %.1f.", char, upper, lower)
+		}
+	}
+
+	// the counter of "o" stays open
+	g := bold.Glyphs[bold.GlyphIndex('o')]
+	centre := bold.Image.(*image.Alpha).AlphaAt(g.X+g.Advance/2, g.Y+bold.Baseline-8).A
+	if centre != 0 {
+		t.Error("The counter of a bold o should stay empty.", centre)
+	}
+}
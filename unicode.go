@@ -0,0 +1,130 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gltext
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// RuneRangesFromTable converts Go's unicode tables, such as unicode.Han or
+// unicode.Cyrillic, into normalized RuneRanges.  Entries with a stride greater
+// than one contribute each of their runes individually.
+func RuneRangesFromTable(tables ...*unicode.RangeTable) RuneRanges {
+	rr := make(RuneRanges, 0)
+	for _, table := range tables {
+		for _, r := range table.R16 {
+			rr = appendStrided(rr, rune(r.Lo), rune(r.Hi), rune(r.Stride))
+		}
+		for _, r := range table.R32 {
+			rr = appendStrided(rr, rune(r.Lo), rune(r.Hi), rune(r.Stride))
+		}
+	}
+	return rr.Normalize()
+}
+
+func appendStrided(rr RuneRanges, low, high, stride rune) RuneRanges {
+	if stride == 1 {
+		return append(rr, RuneRange{Low: low, High: high})
+	}
+	for r := low; r <= high; r += stride {
+		rr = append(rr, RuneRange{Low: r, High: r})
+	}
+	return rr
+}
+
+// looseName applies the loose matching rule of UAX #44: case, whitespace,
+// hyphens and underscores are ignored so "latin extended a" matches "Latin Extended-A".
+func looseName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == '-' || r == '_' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, name)
+}
+
+// LookupRuneRanges returns the runes of the Unicode script or block with the
+// given name, e.g. "Latin", "Hangul" or "CJK Symbols and Punctuation".  Names
+// are matched loosely.  Scripts are preferred when a script and a block share
+// a name; the prefixes "script:" and "block:" select one explicitly.
+//
+// Scripts only hold the runes assigned to them.  The space, digits and
+// punctuation shared between scripts, such as "." and "。" or the "ー" of
+// katakana, belong to the Common script, and combining marks to Inherited.
+// Request "Common" along with other scripts to bake them, or one of the
+// smaller blocks "Basic Latin" and "CJK Symbols and Punctuation".
+func LookupRuneRanges(name string) (RuneRanges, bool) {
+	name = strings.TrimSpace(name)
+	lookupScript, lookupBlock := true, true
+	if lower := strings.ToLower(name); strings.HasPrefix(lower, "script:") {
+		name, lookupBlock = name[len("script:"):], false
+	} else if strings.HasPrefix(lower, "block:") {
+		name, lookupScript = name[len("block:"):], false
+	}
+	loose := looseName(name)
+
+	if lookupScript {
+		for script, table := range unicode.Scripts {
+			if looseName(script) == loose {
+				return RuneRangesFromTable(table), true
+			}
+		}
+	}
+	if lookupBlock {
+		for _, block := range unicodeBlocks {
+			if looseName(block.name) == loose {
+				return RuneRanges{block.runes}, true
+			}
+		}
+	}
+	return nil, false
+}
+
+var (
+	codePointPrefix = regexp.MustCompile(`(?i)\bU\+([0-9A-F]{1,6})\b`)
+	codePointTerm   = regexp.MustCompile(`^#([0-9A-Fa-f]+)(?:\s*-\s*#([0-9A-Fa-f]+))?$`)
+)
+
+// ParseRuneRanges builds RuneRanges from a description such as
+// "Common + Latin + Hiragana + Katakana + Han".  Each term is either a script
+// or block name understood by LookupRuneRanges, a single code point "U+3000"
+// or a code point range "U+0020-U+007E".  The result is normalized.
+//
+// Without "Common", scripts leave out spaces, digits and punctuation; see
+// LookupRuneRanges.
+func ParseRuneRanges(spec string) (RuneRanges, error) {
+	// code points are marked before splitting so that the plus of "U+" survives
+	marked := codePointPrefix.ReplaceAllString(spec, "#$1")
+
+	rr := make(RuneRanges, 0)
+	for _, term := range strings.Split(marked, "+") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			return nil, fmt.Errorf("Empty term in rune range description %q.", spec)
+		}
+		if m := codePointTerm.FindStringSubmatch(term); m != nil {
+			low, _ := strconv.ParseUint(m[1], 16, 32)
+			high := low
+			if m[2] != "" {
+				high, _ = strconv.ParseUint(m[2], 16, 32)
+			}
+			if low > high || high > unicode.MaxRune {
+				return nil, fmt.Errorf("Invalid code point range %q.", strings.Replace(term, "#", "U+", -1))
+			}
+			rr = append(rr, RuneRange{Low: rune(low), High: rune(high)})
+			continue
+		}
+		found, ok := LookupRuneRanges(term)
+		if !ok {
+			return nil, fmt.Errorf("Unknown script or block %q.", term)
+		}
+		rr = append(rr, found...)
+	}
+	return rr.Normalize(), nil
+}
@@ -0,0 +1,333 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gltext
+
+// unicodeBlocks lists the named Unicode blocks, taken from Blocks-14.0.0.txt of
+// the Unicode Character Database.
+var unicodeBlocks = []struct {
+	name  string
+	runes RuneRange
+}{
+	{"Basic Latin", RuneRange{Low: 0x0000, High: 0x007F}},
+	{"Latin-1 Supplement", RuneRange{Low: 0x0080, High: 0x00FF}},
+	{"Latin Extended-A", RuneRange{Low: 0x0100, High: 0x017F}},
+	{"Latin Extended-B", RuneRange{Low: 0x0180, High: 0x024F}},
+	{"IPA Extensions", RuneRange{Low: 0x0250, High: 0x02AF}},
+	{"Spacing Modifier Letters", RuneRange{Low: 0x02B0, High: 0x02FF}},
+	{"Combining Diacritical Marks", RuneRange{Low: 0x0300, High: 0x036F}},
+	{"Greek and Coptic", RuneRange{Low: 0x0370, High: 0x03FF}},
+	{"Cyrillic", RuneRange{Low: 0x0400, High: 0x04FF}},
+	{"Cyrillic Supplement", RuneRange{Low: 0x0500, High: 0x052F}},
+	{"Armenian", RuneRange{Low: 0x0530, High: 0x058F}},
+	{"Hebrew", RuneRange{Low: 0x0590, High: 0x05FF}},
+	{"Arabic", RuneRange{Low: 0x0600, High: 0x06FF}},
+	{"Syriac", RuneRange{Low: 0x0700, High: 0x074F}},
+	{"Arabic Supplement", RuneRange{Low: 0x0750, High: 0x077F}},
+	{"Thaana", RuneRange{Low: 0x0780, High: 0x07BF}},
+	{"NKo", RuneRange{Low: 0x07C0, High: 0x07FF}},
+	{"Samaritan", RuneRange{Low: 0x0800, High: 0x083F}},
+	{"Mandaic", RuneRange{Low: 0x0840, High: 0x085F}},
+	{"Syriac Supplement", RuneRange{Low: 0x0860, High: 0x086F}},
+	{"Arabic Extended-B", RuneRange{Low: 0x0870, High: 0x089F}},
+	{"Arabic Extended-A", RuneRange{Low: 0x08A0, High: 0x08FF}},
+	{"Devanagari", RuneRange{Low: 0x0900, High: 0x097F}},
+	{"Bengali", RuneRange{Low: 0x0980, High: 0x09FF}},
+	{"Gurmukhi", RuneRange{Low: 0x0A00, High: 0x0A7F}},
+	{"Gujarati", RuneRange{Low: 0x0A80, High: 0x0AFF}},
+	{"Oriya", RuneRange{Low: 0x0B00, High: 0x0B7F}},
+	{"Tamil", RuneRange{Low: 0x0B80, High: 0x0BFF}},
+	{"Telugu", RuneRange{Low: 0x0C00, High: 0x0C7F}},
+	{"Kannada", RuneRange{Low: 0x0C80, High: 0x0CFF}},
+	{"Malayalam", RuneRange{Low: 0x0D00, High: 0x0D7F}},
+	{"Sinhala", RuneRange{Low: 0x0D80, High: 0x0DFF}},
+	{"Thai", RuneRange{Low: 0x0E00, High: 0x0E7F}},
+	{"Lao", RuneRange{Low: 0x0E80, High: 0x0EFF}},
+	{"Tibetan", RuneRange{Low: 0x0F00, High: 0x0FFF}},
+	{"Myanmar", RuneRange{Low: 0x1000, High: 0x109F}},
+	{"Georgian", RuneRange{Low: 0x10A0, High: 0x10FF}},
+	{"Hangul Jamo", RuneRange{Low: 0x1100, High: 0x11FF}},
+	{"Ethiopic", RuneRange{Low: 0x1200, High: 0x137F}},
+	{"Ethiopic Supplement", RuneRange{Low: 0x1380, High: 0x139F}},
+	{"Cherokee", RuneRange{Low: 0x13A0, High: 0x13FF}},
+	{"Unified Canadian Aboriginal Syllabics", RuneRange{Low: 0x1400, High: 0x167F}},
+	{"Ogham", RuneRange{Low: 0x1680, High: 0x169F}},
+	{"Runic", RuneRange{Low: 0x16A0, High: 0x16FF}},
+	{"Tagalog", RuneRange{Low: 0x1700, High: 0x171F}},
+	{"Hanunoo", RuneRange{Low: 0x1720, High: 0x173F}},
+	{"Buhid", RuneRange{Low: 0x1740, High: 0x175F}},
+	{"Tagbanwa", RuneRange{Low: 0x1760, High: 0x177F}},
+	{"Khmer", RuneRange{Low: 0x1780, High: 0x17FF}},
+	{"Mongolian", RuneRange{Low: 0x1800, High: 0x18AF}},
+	{"Unified Canadian Aboriginal Syllabics Extended", RuneRange{Low: 0x18B0, High: 0x18FF}},
+	{"Limbu", RuneRange{Low: 0x1900, High: 0x194F}},
+	{"Tai Le", RuneRange{Low: 0x1950, High: 0x197F}},
+	{"New Tai Lue", RuneRange{Low: 0x1980, High: 0x19DF}},
+	{"Khmer Symbols", RuneRange{Low: 0x19E0, High: 0x19FF}},
+	{"Buginese", RuneRange{Low: 0x1A00, High: 0x1A1F}},
+	{"Tai Tham", RuneRange{Low: 0x1A20, High: 0x1AAF}},
+	{"Combining Diacritical Marks Extended", RuneRange{Low: 0x1AB0, High: 0x1AFF}},
+	{"Balinese", RuneRange{Low: 0x1B00, High: 0x1B7F}},
+	{"Sundanese", RuneRange{Low: 0x1B80, High: 0x1BBF}},
+	{"Batak", RuneRange{Low: 0x1BC0, High: 0x1BFF}},
+	{"Lepcha", RuneRange{Low: 0x1C00, High: 0x1C4F}},
+	{"Ol Chiki", RuneRange{Low: 0x1C50, High: 0x1C7F}},
+	{"Cyrillic Extended-C", RuneRange{Low: 0x1C80, High: 0x1C8F}},
+	{"Georgian Extended", RuneRange{Low: 0x1C90, High: 0x1CBF}},
+	{"Sundanese Supplement", RuneRange{Low: 0x1CC0, High: 0x1CCF}},
+	{"Vedic Extensions", RuneRange{Low: 0x1CD0, High: 0x1CFF}},
+	{"Phonetic Extensions", RuneRange{Low: 0x1D00, High: 0x1D7F}},
+	{"Phonetic Extensions Supplement", RuneRange{Low: 0x1D80, High: 0x1DBF}},
+	{"Combining Diacritical Marks Supplement", RuneRange{Low: 0x1DC0, High: 0x1DFF}},
+	{"Latin Extended Additional", RuneRange{Low: 0x1E00, High: 0x1EFF}},
+	{"Greek Extended", RuneRange{Low: 0x1F00, High: 0x1FFF}},
+	{"General Punctuation", RuneRange{Low: 0x2000, High: 0x206F}},
+	{"Superscripts and Subscripts", RuneRange{Low: 0x2070, High: 0x209F}},
+	{"Currency Symbols", RuneRange{Low: 0x20A0, High: 0x20CF}},
+	{"Combining Diacritical Marks for Symbols", RuneRange{Low: 0x20D0, High: 0x20FF}},
+	{"Letterlike Symbols", RuneRange{Low: 0x2100, High: 0x214F}},
+	{"Number Forms", RuneRange{Low: 0x2150, High: 0x218F}},
+	{"Arrows", RuneRange{Low: 0x2190, High: 0x21FF}},
+	{"Mathematical Operators", RuneRange{Low: 0x2200, High: 0x22FF}},
+	{"Miscellaneous Technical", RuneRange{Low: 0x2300, High: 0x23FF}},
+	{"Control Pictures", RuneRange{Low: 0x2400, High: 0x243F}},
+	{"Optical Character Recognition", RuneRange{Low: 0x2440, High: 0x245F}},
+	{"Enclosed Alphanumerics", RuneRange{Low: 0x2460, High: 0x24FF}},
+	{"Box Drawing", RuneRange{Low: 0x2500, High: 0x257F}},
+	{"Block Elements", RuneRange{Low: 0x2580, High: 0x259F}},
+	{"Geometric Shapes", RuneRange{Low: 0x25A0, High: 0x25FF}},
+	{"Miscellaneous Symbols", RuneRange{Low: 0x2600, High: 0x26FF}},
+	{"Dingbats", RuneRange{Low: 0x2700, High: 0x27BF}},
+	{"Miscellaneous Mathematical Symbols-A", RuneRange{Low: 0x27C0, High: 0x27EF}},
+	{"Supplemental Arrows-A", RuneRange{Low: 0x27F0, High: 0x27FF}},
+	{"Braille Patterns", RuneRange{Low: 0x2800, High: 0x28FF}},
+	{"Supplemental Arrows-B", RuneRange{Low: 0x2900, High: 0x297F}},
+	{"Miscellaneous Mathematical Symbols-B", RuneRange{Low: 0x2980, High: 0x29FF}},
+	{"Supplemental Mathematical Operators", RuneRange{Low: 0x2A00, High: 0x2AFF}},
+	{"Miscellaneous Symbols and Arrows", RuneRange{Low: 0x2B00, High: 0x2BFF}},
+	{"Glagolitic", RuneRange{Low: 0x2C00, High: 0x2C5F}},
+	{"Latin Extended-C", RuneRange{Low: 0x2C60, High: 0x2C7F}},
+	{"Coptic", RuneRange{Low: 0x2C80, High: 0x2CFF}},
+	{"Georgian Supplement", RuneRange{Low: 0x2D00, High: 0x2D2F}},
+	{"Tifinagh", RuneRange{Low: 0x2D30, High: 0x2D7F}},
+	{"Ethiopic Extended", RuneRange{Low: 0x2D80, High: 0x2DDF}},
+	{"Cyrillic Extended-A", RuneRange{Low: 0x2DE0, High: 0x2DFF}},
+	{"Supplemental Punctuation", RuneRange{Low: 0x2E00, High: 0x2E7F}},
+	{"CJK Radicals Supplement", RuneRange{Low: 0x2E80, High: 0x2EFF}},
+	{"Kangxi Radicals", RuneRange{Low: 0x2F00, High: 0x2FDF}},
+	{"Ideographic Description Characters", RuneRange{Low: 0x2FF0, High: 0x2FFF}},
+	{"CJK Symbols and Punctuation", RuneRange{Low: 0x3000, High: 0x303F}},
+	{"Hiragana", RuneRange{Low: 0x3040, High: 0x309F}},
+	{"Katakana", RuneRange{Low: 0x30A0, High: 0x30FF}},
+	{"Bopomofo", RuneRange{Low: 0x3100, High: 0x312F}},
+	{"Hangul Compatibility Jamo", RuneRange{Low: 0x3130, High: 0x318F}},
+	{"Kanbun", RuneRange{Low: 0x3190, High: 0x319F}},
+	{"Bopomofo Extended", RuneRange{Low: 0x31A0, High: 0x31BF}},
+	{"CJK Strokes", RuneRange{Low: 0x31C0, High: 0x31EF}},
+	{"Katakana Phonetic Extensions", RuneRange{Low: 0x31F0, High: 0x31FF}},
+	{"Enclosed CJK Letters and Months", RuneRange{Low: 0x3200, High: 0x32FF}},
+	{"CJK Compatibility", RuneRange{Low: 0x3300, High: 0x33FF}},
+	{"CJK Unified Ideographs Extension A", RuneRange{Low: 0x3400, High: 0x4DBF}},
+	{"Yijing Hexagram Symbols", RuneRange{Low: 0x4DC0, High: 0x4DFF}},
+	{"CJK Unified Ideographs", RuneRange{Low: 0x4E00, High: 0x9FFF}},
+	{"Yi Syllables", RuneRange{Low: 0xA000, High: 0xA48F}},
+	{"Yi Radicals", RuneRange{Low: 0xA490, High: 0xA4CF}},
+	{"Lisu", RuneRange{Low: 0xA4D0, High: 0xA4FF}},
+	{"Vai", RuneRange{Low: 0xA500, High: 0xA63F}},
+	{"Cyrillic Extended-B", RuneRange{Low: 0xA640, High: 0xA69F}},
+	{"Bamum", RuneRange{Low: 0xA6A0, High: 0xA6FF}},
+	{"Modifier Tone Letters", RuneRange{Low: 0xA700, High: 0xA71F}},
+	{"Latin Extended-D", RuneRange{Low: 0xA720, High: 0xA7FF}},
+	{"Syloti Nagri", RuneRange{Low: 0xA800, High: 0xA82F}},
+	{"Common Indic Number Forms", RuneRange{Low: 0xA830, High: 0xA83F}},
+	{"Phags-pa", RuneRange{Low: 0xA840, High: 0xA87F}},
+	{"Saurashtra", RuneRange{Low: 0xA880, High: 0xA8DF}},
+	{"Devanagari Extended", RuneRange{Low: 0xA8E0, High: 0xA8FF}},
+	{"Kayah Li", RuneRange{Low: 0xA900, High: 0xA92F}},
+	{"Rejang", RuneRange{Low: 0xA930, High: 0xA95F}},
+	{"Hangul Jamo Extended-A", RuneRange{Low: 0xA960, High: 0xA97F}},
+	{"Javanese", RuneRange{Low: 0xA980, High: 0xA9DF}},
+	{"Myanmar Extended-B", RuneRange{Low: 0xA9E0, High: 0xA9FF}},
+	{"Cham", RuneRange{Low: 0xAA00, High: 0xAA5F}},
+	{"Myanmar Extended-A", RuneRange{Low: 0xAA60, High: 0xAA7F}},
+	{"Tai Viet", RuneRange{Low: 0xAA80, High: 0xAADF}},
+	{"Meetei Mayek Extensions", RuneRange{Low: 0xAAE0, High: 0xAAFF}},
+	{"Ethiopic Extended-A", RuneRange{Low: 0xAB00, High: 0xAB2F}},
+	{"Latin Extended-E", RuneRange{Low: 0xAB30, High: 0xAB6F}},
+	{"Cherokee Supplement", RuneRange{Low: 0xAB70, High: 0xABBF}},
+	{"Meetei Mayek", RuneRange{Low: 0xABC0, High: 0xABFF}},
+	{"Hangul Syllables", RuneRange{Low: 0xAC00, High: 0xD7AF}},
+	{"Hangul Jamo Extended-B", RuneRange{Low: 0xD7B0, High: 0xD7FF}},
+	{"High Surrogates", RuneRange{Low: 0xD800, High: 0xDB7F}},
+	{"High Private Use Surrogates", RuneRange{Low: 0xDB80, High: 0xDBFF}},
+	{"Low Surrogates", RuneRange{Low: 0xDC00, High: 0xDFFF}},
+	{"Private Use Area", RuneRange{Low: 0xE000, High: 0xF8FF}},
+	{"CJK Compatibility Ideographs", RuneRange{Low: 0xF900, High: 0xFAFF}},
+	{"Alphabetic Presentation Forms", RuneRange{Low: 0xFB00, High: 0xFB4F}},
+	{"Arabic Presentation Forms-A", RuneRange{Low: 0xFB50, High: 0xFDFF}},
+	{"Variation Selectors", RuneRange{Low: 0xFE00, High: 0xFE0F}},
+	{"Vertical Forms", RuneRange{Low: 0xFE10, High: 0xFE1F}},
+	{"Combining Half Marks", RuneRange{Low: 0xFE20, High: 0xFE2F}},
+	{"CJK Compatibility Forms", RuneRange{Low: 0xFE30, High: 0xFE4F}},
+	{"Small Form Variants", RuneRange{Low: 0xFE50, High: 0xFE6F}},
+	{"Arabic Presentation Forms-B", RuneRange{Low: 0xFE70, High: 0xFEFF}},
+	{"Halfwidth and Fullwidth Forms", RuneRange{Low: 0xFF00, High: 0xFFEF}},
+	{"Specials", RuneRange{Low: 0xFFF0, High: 0xFFFF}},
+	{"Linear B Syllabary", RuneRange{Low: 0x10000, High: 0x1007F}},
+	{"Linear B Ideograms", RuneRange{Low: 0x10080, High: 0x100FF}},
+	{"Aegean Numbers", RuneRange{Low: 0x10100, High: 0x1013F}},
+	{"Ancient Greek Numbers", RuneRange{Low: 0x10140, High: 0x1018F}},
+	{"Ancient Symbols", RuneRange{Low: 0x10190, High: 0x101CF}},
+	{"Phaistos Disc", RuneRange{Low: 0x101D0, High: 0x101FF}},
+	{"Lycian", RuneRange{Low: 0x10280, High: 0x1029F}},
+	{"Carian", RuneRange{Low: 0x102A0, High: 0x102DF}},
+	{"Coptic Epact Numbers", RuneRange{Low: 0x102E0, High: 0x102FF}},
+	{"Old Italic", RuneRange{Low: 0x10300, High: 0x1032F}},
+	{"Gothic", RuneRange{Low: 0x10330, High: 0x1034F}},
+	{"Old Permic", RuneRange{Low: 0x10350, High: 0x1037F}},
+	{"Ugaritic", RuneRange{Low: 0x10380, High: 0x1039F}},
+	{"Old Persian", RuneRange{Low: 0x103A0, High: 0x103DF}},
+	{"Deseret", RuneRange{Low: 0x10400, High: 0x1044F}},
+	{"Shavian", RuneRange{Low: 0x10450, High: 0x1047F}},
+	{"Osmanya", RuneRange{Low: 0x10480, High: 0x104AF}},
+	{"Osage", RuneRange{Low: 0x104B0, High: 0x104FF}},
+	{"Elbasan", RuneRange{Low: 0x10500, High: 0x1052F}},
+	{"Caucasian Albanian", RuneRange{Low: 0x10530, High: 0x1056F}},
+	{"Vithkuqi", RuneRange{Low: 0x10570, High: 0x105BF}},
+	{"Linear A", RuneRange{Low: 0x10600, High: 0x1077F}},
+	{"Latin Extended-F", RuneRange{Low: 0x10780, High: 0x107BF}},
+	{"Cypriot Syllabary", RuneRange{Low: 0x10800, High: 0x1083F}},
+	{"Imperial Aramaic", RuneRange{Low: 0x10840, High: 0x1085F}},
+	{"Palmyrene", RuneRange{Low: 0x10860, High: 0x1087F}},
+	{"Nabataean", RuneRange{Low: 0x10880, High: 0x108AF}},
+	{"Hatran", RuneRange{Low: 0x108E0, High: 0x108FF}},
+	{"Phoenician", RuneRange{Low: 0x10900, High: 0x1091F}},
+	{"Lydian", RuneRange{Low: 0x10920, High: 0x1093F}},
+	{"Meroitic Hieroglyphs", RuneRange{Low: 0x10980, High: 0x1099F}},
+	{"Meroitic Cursive", RuneRange{Low: 0x109A0, High: 0x109FF}},
+	{"Kharoshthi", RuneRange{Low: 0x10A00, High: 0x10A5F}},
+	{"Old South Arabian", RuneRange{Low: 0x10A60, High: 0x10A7F}},
+	{"Old North Arabian", RuneRange{Low: 0x10A80, High: 0x10A9F}},
+	{"Manichaean", RuneRange{Low: 0x10AC0, High: 0x10AFF}},
+	{"Avestan", RuneRange{Low: 0x10B00, High: 0x10B3F}},
+	{"Inscriptional Parthian", RuneRange{Low: 0x10B40, High: 0x10B5F}},
+	{"Inscriptional Pahlavi", RuneRange{Low: 0x10B60, High: 0x10B7F}},
+	{"Psalter Pahlavi", RuneRange{Low: 0x10B80, High: 0x10BAF}},
+	{"Old Turkic", RuneRange{Low: 0x10C00, High: 0x10C4F}},
+	{"Old Hungarian", RuneRange{Low: 0x10C80, High: 0x10CFF}},
+	{"Hanifi Rohingya", RuneRange{Low: 0x10D00, High: 0x10D3F}},
+	{"Rumi Numeral Symbols", RuneRange{Low: 0x10E60, High: 0x10E7F}},
+	{"Yezidi", RuneRange{Low: 0x10E80, High: 0x10EBF}},
+	{"Old Sogdian", RuneRange{Low: 0x10F00, High: 0x10F2F}},
+	{"Sogdian", RuneRange{Low: 0x10F30, High: 0x10F6F}},
+	{"Old Uyghur", RuneRange{Low: 0x10F70, High: 0x10FAF}},
+	{"Chorasmian", RuneRange{Low: 0x10FB0, High: 0x10FDF}},
+	{"Elymaic", RuneRange{Low: 0x10FE0, High: 0x10FFF}},
+	{"Brahmi", RuneRange{Low: 0x11000, High: 0x1107F}},
+	{"Kaithi", RuneRange{Low: 0x11080, High: 0x110CF}},
+	{"Sora Sompeng", RuneRange{Low: 0x110D0, High: 0x110FF}},
+	{"Chakma", RuneRange{Low: 0x11100, High: 0x1114F}},
+	{"Mahajani", RuneRange{Low: 0x11150, High: 0x1117F}},
+	{"Sharada", RuneRange{Low: 0x11180, High: 0x111DF}},
+	{"Sinhala Archaic Numbers", RuneRange{Low: 0x111E0, High: 0x111FF}},
+	{"Khojki", RuneRange{Low: 0x11200, High: 0x1124F}},
+	{"Multani", RuneRange{Low: 0x11280, High: 0x112AF}},
+	{"Khudawadi", RuneRange{Low: 0x112B0, High: 0x112FF}},
+	{"Grantha", RuneRange{Low: 0x11300, High: 0x1137F}},
+	{"Newa", RuneRange{Low: 0x11400, High: 0x1147F}},
+	{"Tirhuta", RuneRange{Low: 0x11480, High: 0x114DF}},
+	{"Siddham", RuneRange{Low: 0x11580, High: 0x115FF}},
+	{"Modi", RuneRange{Low: 0x11600, High: 0x1165F}},
+	{"Mongolian Supplement", RuneRange{Low: 0x11660, High: 0x1167F}},
+	{"Takri", RuneRange{Low: 0x11680, High: 0x116CF}},
+	{"Ahom", RuneRange{Low: 0x11700, High: 0x1174F}},
+	{"Dogra", RuneRange{Low: 0x11800, High: 0x1184F}},
+	{"Warang Citi", RuneRange{Low: 0x118A0, High: 0x118FF}},
+	{"Dives Akuru", RuneRange{Low: 0x11900, High: 0x1195F}},
+	{"Nandinagari", RuneRange{Low: 0x119A0, High: 0x119FF}},
+	{"Zanabazar Square", RuneRange{Low: 0x11A00, High: 0x11A4F}},
+	{"Soyombo", RuneRange{Low: 0x11A50, High: 0x11AAF}},
+	{"Unified Canadian Aboriginal Syllabics Extended-A", RuneRange{Low: 0x11AB0, High: 0x11ABF}},
+	{"Pau Cin Hau", RuneRange{Low: 0x11AC0, High: 0x11AFF}},
+	{"Bhaiksuki", RuneRange{Low: 0x11C00, High: 0x11C6F}},
+	{"Marchen", RuneRange{Low: 0x11C70, High: 0x11CBF}},
+	{"Masaram Gondi", RuneRange{Low: 0x11D00, High: 0x11D5F}},
+	{"Gunjala Gondi", RuneRange{Low: 0x11D60, High: 0x11DAF}},
+	{"Makasar", RuneRange{Low: 0x11EE0, High: 0x11EFF}},
+	{"Lisu Supplement", RuneRange{Low: 0x11FB0, High: 0x11FBF}},
+	{"Tamil Supplement", RuneRange{Low: 0x11FC0, High: 0x11FFF}},
+	{"Cuneiform", RuneRange{Low: 0x12000, High: 0x123FF}},
+	{"Cuneiform Numbers and Punctuation", RuneRange{Low: 0x12400, High: 0x1247F}},
+	{"Early Dynastic Cuneiform", RuneRange{Low: 0x12480, High: 0x1254F}},
+	{"Cypro-Minoan", RuneRange{Low: 0x12F90, High: 0x12FFF}},
+	{"Egyptian Hieroglyphs", RuneRange{Low: 0x13000, High: 0x1342F}},
+	{"Egyptian Hieroglyph Format Controls", RuneRange{Low: 0x13430, High: 0x1343F}},
+	{"Anatolian Hieroglyphs", RuneRange{Low: 0x14400, High: 0x1467F}},
+	{"Bamum Supplement", RuneRange{Low: 0x16800, High: 0x16A3F}},
+	{"Mro", RuneRange{Low: 0x16A40, High: 0x16A6F}},
+	{"Tangsa", RuneRange{Low: 0x16A70, High: 0x16ACF}},
+	{"Bassa Vah", RuneRange{Low: 0x16AD0, High: 0x16AFF}},
+	{"Pahawh Hmong", RuneRange{Low: 0x16B00, High: 0x16B8F}},
+	{"Medefaidrin", RuneRange{Low: 0x16E40, High: 0x16E9F}},
+	{"Miao", RuneRange{Low: 0x16F00, High: 0x16F9F}},
+	{"Ideographic Symbols and Punctuation", RuneRange{Low: 0x16FE0, High: 0x16FFF}},
+	{"Tangut", RuneRange{Low: 0x17000, High: 0x187FF}},
+	{"Tangut Components", RuneRange{Low: 0x18800, High: 0x18AFF}},
+	{"Khitan Small Script", RuneRange{Low: 0x18B00, High: 0x18CFF}},
+	{"Tangut Supplement", RuneRange{Low: 0x18D00, High: 0x18D7F}},
+	{"Kana Extended-B", RuneRange{Low: 0x1AFF0, High: 0x1AFFF}},
+	{"Kana Supplement", RuneRange{Low: 0x1B000, High: 0x1B0FF}},
+	{"Kana Extended-A", RuneRange{Low: 0x1B100, High: 0x1B12F}},
+	{"Small Kana Extension", RuneRange{Low: 0x1B130, High: 0x1B16F}},
+	{"Nushu", RuneRange{Low: 0x1B170, High: 0x1B2FF}},
+	{"Duployan", RuneRange{Low: 0x1BC00, High: 0x1BC9F}},
+	{"Shorthand Format Controls", RuneRange{Low: 0x1BCA0, High: 0x1BCAF}},
+	{"Znamenny Musical Notation", RuneRange{Low: 0x1CF00, High: 0x1CFCF}},
+	{"Byzantine Musical Symbols", RuneRange{Low: 0x1D000, High: 0x1D0FF}},
+	{"Musical Symbols", RuneRange{Low: 0x1D100, High: 0x1D1FF}},
+	{"Ancient Greek Musical Notation", RuneRange{Low: 0x1D200, High: 0x1D24F}},
+	{"Mayan Numerals", RuneRange{Low: 0x1D2E0, High: 0x1D2FF}},
+	{"Tai Xuan Jing Symbols", RuneRange{Low: 0x1D300, High: 0x1D35F}},
+	{"Counting Rod Numerals", RuneRange{Low: 0x1D360, High: 0x1D37F}},
+	{"Mathematical Alphanumeric Symbols", RuneRange{Low: 0x1D400, High: 0x1D7FF}},
+	{"Sutton SignWriting", RuneRange{Low: 0x1D800, High: 0x1DAAF}},
+	{"Latin Extended-G", RuneRange{Low: 0x1DF00, High: 0x1DFFF}},
+	{"Glagolitic Supplement", RuneRange{Low: 0x1E000, High: 0x1E02F}},
+	{"Nyiakeng Puachue Hmong", RuneRange{Low: 0x1E100, High: 0x1E14F}},
+	{"Toto", RuneRange{Low: 0x1E290, High: 0x1E2BF}},
+	{"Wancho", RuneRange{Low: 0x1E2C0, High: 0x1E2FF}},
+	{"Ethiopic Extended-B", RuneRange{Low: 0x1E7E0, High: 0x1E7FF}},
+	{"Mende Kikakui", RuneRange{Low: 0x1E800, High: 0x1E8DF}},
+	{"Adlam", RuneRange{Low: 0x1E900, High: 0x1E95F}},
+	{"Indic Siyaq Numbers", RuneRange{Low: 0x1EC70, High: 0x1ECBF}},
+	{"Ottoman Siyaq Numbers", RuneRange{Low: 0x1ED00, High: 0x1ED4F}},
+	{"Arabic Mathematical Alphabetic Symbols", RuneRange{Low: 0x1EE00, High: 0x1EEFF}},
+	{"Mahjong Tiles", RuneRange{Low: 0x1F000, High: 0x1F02F}},
+	{"Domino Tiles", RuneRange{Low: 0x1F030, High: 0x1F09F}},
+	{"Playing Cards", RuneRange{Low: 0x1F0A0, High: 0x1F0FF}},
+	{"Enclosed Alphanumeric Supplement", RuneRange{Low: 0x1F100, High: 0x1F1FF}},
+	{"Enclosed Ideographic Supplement", RuneRange{Low: 0x1F200, High: 0x1F2FF}},
+	{"Miscellaneous Symbols and Pictographs", RuneRange{Low: 0x1F300, High: 0x1F5FF}},
+	{"Emoticons", RuneRange{Low: 0x1F600, High: 0x1F64F}},
+	{"Ornamental Dingbats", RuneRange{Low: 0x1F650, High: 0x1F67F}},
+	{"Transport and Map Symbols", RuneRange{Low: 0x1F680, High: 0x1F6FF}},
+	{"Alchemical Symbols", RuneRange{Low: 0x1F700, High: 0x1F77F}},
+	{"Geometric Shapes Extended", RuneRange{Low: 0x1F780, High: 0x1F7FF}},
+	{"Supplemental Arrows-C", RuneRange{Low: 0x1F800, High: 0x1F8FF}},
+	{"Supplemental Symbols and Pictographs", RuneRange{Low: 0x1F900, High: 0x1F9FF}},
+	{"Chess Symbols", RuneRange{Low: 0x1FA00, High: 0x1FA6F}},
+	{"Symbols and Pictographs Extended-A", RuneRange{Low: 0x1FA70, High: 0x1FAFF}},
+	{"Symbols for Legacy Computing", RuneRange{Low: 0x1FB00, High: 0x1FBFF}},
+	{"CJK Unified Ideographs Extension B", RuneRange{Low: 0x20000, High: 0x2A6DF}},
+	{"CJK Unified Ideographs Extension C", RuneRange{Low: 0x2A700, High: 0x2B73F}},
+	{"CJK Unified Ideographs Extension D", RuneRange{Low: 0x2B740, High: 0x2B81F}},
+	{"CJK Unified Ideographs Extension E", RuneRange{Low: 0x2B820, High: 0x2CEAF}},
+	{"CJK Unified Ideographs Extension F", RuneRange{Low: 0x2CEB0, High: 0x2EBEF}},
+	{"CJK Compatibility Ideographs Supplement", RuneRange{Low: 0x2F800, High: 0x2FA1F}},
+	{"CJK Unified Ideographs Extension G", RuneRange{Low: 0x30000, High: 0x3134F}},
+	{"Tags", RuneRange{Low: 0xE0000, High: 0xE007F}},
+	{"Variation Selectors Supplement", RuneRange{Low: 0xE0100, High: 0xE01EF}},
+	{"Supplementary Private Use Area-A", RuneRange{Low: 0xF0000, High: 0xFFFFF}},
+	{"Supplementary Private Use Area-B", RuneRange{Low: 0x100000, High: 0x10FFFF}},
+}
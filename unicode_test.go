@@ -0,0 +1,62 @@
+package gltext
+
+import (
+	"reflect"
+	"testing"
+	"unicode"
+)
+
+func TestRuneRangesFromTable(t *testing.T) {
+	table := &unicode.RangeTable{
+		R16: []unicode.Range16{{Lo: 0x41, Hi: 0x45, Stride: 2}, {Lo: 0x50, Hi: 0x52, Stride: 1}},
+	}
+	expected := RuneRanges{{Low: 0x41, High: 0x41}, {Low: 0x43, High: 0x43}, {Low: 0x45, High: 0x45}, {Low: 0x50, High: 0x52}}
+	if rr := RuneRangesFromTable(table); !reflect.DeepEqual(rr, expected) {
+		t.Error("Stride not honoured", rr)
+	}
+
+	han := RuneRangesFromTable(unicode.Han)
+	if !han.Contains('大') || han.Contains('a') {
+		t.Error("Unexpected Han ranges")
+	}
+}
+
+func TestParseRuneRanges(t *testing.T) {
+	rr, err := ParseRuneRanges("U+0020-U+007E + cjk symbols and punctuation + Hiragana + block:Katakana + U+FF01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []rune{' ', '~', '　', 'あ', 'ア', '！'} {
+		if !rr.Contains(r) {
+			t.Errorf("Missing %U", r)
+		}
+	}
+	if rr.Contains('\u007f') || rr.Contains('漢') {
+		t.Error("Unexpected runes included")
+	}
+
+	// scripts leave out shared runes, which Common adds
+	rr, err = ParseRuneRanges("Latin + Katakana")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rr.Contains(' ') || rr.Contains('0') || rr.Contains('ー') {
+		t.Error("Scripts should not hold runes of the Common script")
+	}
+	rr, err = ParseRuneRanges("Common + Latin + Katakana")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []rune{' ', '0', '.', 'ー', '。', 'a', 'ア'} {
+		if !rr.Contains(r) {
+			t.Errorf("Missing %U", r)
+		}
+	}
+
+	if _, err = ParseRuneRanges("Latin + Klingon"); err == nil {
+		t.Error("Expecting an unknown name error.")
+	}
+	if _, err = ParseRuneRanges("U+0100-U+0020"); err == nil {
+		t.Error("Expecting an invalid range error.")
+	}
+}
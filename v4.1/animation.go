@@ -0,0 +1,197 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package v41
+
+import (
+	"github.com/4ydx/gltext"
+	"github.com/go-gl/mathgl/mgl32"
+	"math"
+)
+
+// Animation changes a property of a Text over time.  Animations are created by
+// FadeIn, FadeOut, FadeTo, Pulse, MoveTo and ColorTo, adjusted through their fields
+// and started with Text.Animate.  Their starting values are taken from the text once
+// the delay has passed.  When several animations change the same property, the one
+// started last wins.
+type Animation struct {
+	Duration float32 // seconds
+	Delay    float32 // seconds before the animation starts
+	Easing   gltext.Easing
+
+	// Repeat starts the animation over once it completes.  Repeating animations never
+	// complete on their own.
+	Repeat bool
+
+	// Alternate runs every other repetition backwards.
+	Alternate bool
+
+	// OnComplete is called with the text once the animation has finished.
+	OnComplete func(t *Text)
+
+	start   func(t *Text)
+	step    func(t *Text, progress float32)
+	elapsed float32
+	cycle   int
+	started bool
+	done    bool
+}
+
+// Done reports whether the animation has finished or was stopped.
+func (a *Animation) Done() bool {
+	return a.done
+}
+
+// Stop ends the animation where it is without calling OnComplete.
+func (a *Animation) Stop() {
+	a.done = true
+}
+
+// update advances the animation by dt seconds and returns true when it completed.
+func (a *Animation) update(t *Text, dt float32) bool {
+	if a.done {
+		return false
+	}
+	a.elapsed += dt
+	if a.elapsed < a.Delay {
+		return false
+	}
+	if !a.started {
+		a.started = true
+		if a.start != nil {
+			a.start(t)
+		}
+	}
+	progress := float32(1)
+	if a.Duration > 0 {
+		progress = (a.elapsed - a.Delay) / a.Duration
+	}
+	if progress >= 1 {
+		if a.Repeat && a.Duration > 0 {
+			a.cycle += int(progress)
+			progress = float32(math.Mod(float64(progress), 1))
+			a.elapsed = a.Delay + progress*a.Duration
+		} else {
+			progress = 1
+			a.done = true
+		}
+	}
+	if a.Alternate && a.cycle%2 == 1 {
+		progress = 1 - progress
+	}
+	easing := a.Easing
+	if easing == nil {
+		easing = gltext.Linear
+	}
+	a.step(t, easing(progress))
+	return a.done
+}
+
+// FadeTo changes the opacity of the text to alpha.
+func FadeTo(alpha, duration float32) *Animation {
+	var from float32
+	return &Animation{
+		Duration: duration,
+		start:    func(t *Text) { from = t.color[3] },
+		step:     func(t *Text, p float32) { t.SetAlpha(from + (alpha-from)*p) },
+	}
+}
+
+// FadeIn makes the text appear, starting from full transparency.  Set the alpha to
+// 0 beforehand when the fade in is delayed.
+func FadeIn(duration float32) *Animation {
+	return &Animation{
+		Duration: duration,
+		step:     func(t *Text, p float32) { t.SetAlpha(p) },
+	}
+}
+
+// FadeOut makes the text disappear.
+func FadeOut(duration float32) *Animation {
+	return FadeTo(0, duration)
+}
+
+// Pulse scales the text from ScaleMin to ScaleMax and back within each period.
+// It repeats until stopped.
+func Pulse(period float32) *Animation {
+	return &Animation{
+		Duration:  period / 2,
+		Easing:    gltext.EaseInOutSine,
+		Repeat:    true,
+		Alternate: true,
+		step: func(t *Text, p float32) {
+			t.SetScale(t.ScaleMin + (t.ScaleMax-t.ScaleMin)*p)
+		},
+	}
+}
+
+// MoveTo slides the text to the screen position.
+func MoveTo(position mgl32.Vec2, duration float32) *Animation {
+	var from mgl32.Vec2
+	return &Animation{
+		Duration: duration,
+		start:    func(t *Text) { from = t.Position },
+		step: func(t *Text, p float32) {
+			t.SetPosition(from.Add(position.Sub(from).Mul(p)))
+		},
+	}
+}
+
+// ColorTo changes the color and opacity of the text to the rgba color.
+func ColorTo(color mgl32.Vec4, duration float32) *Animation {
+	var from mgl32.Vec4
+	return &Animation{
+		Duration: duration,
+		start:    func(t *Text) { from = t.color },
+		step: func(t *Text, p float32) {
+			t.SetRGBA(from.Add(color.Sub(from).Mul(p)))
+		},
+	}
+}
+
+// Animate starts the animations.  They progress with every call to Update.
+func (t *Text) Animate(animations ...*Animation) {
+	t.animations = append(t.animations, animations...)
+}
+
+// StopAnimations stops every animation of the text without calling OnComplete.
+func (t *Text) StopAnimations() {
+	for _, a := range t.animations {
+		a.Stop()
+	}
+	t.animations = nil
+}
+
+// Animating reports whether any animation is still running.
+func (t *Text) Animating() bool {
+	return len(t.animations) > 0
+}
+
+// Update advances the animations, the typewriter and the effects of the text by dt
+// seconds, calling the OnComplete callbacks of those that finish.  It is meant to be
+// called once per frame, before Draw, with the time elapsed since the previous frame
+// so that animations run at the same speed whatever the frame rate.
+func (t *Text) Update(dt float32) {
+	t.effectTime += dt
+	if t.typewriter != nil {
+		t.typewriter.update(dt)
+	}
+	animations := t.animations
+	t.animations = make([]*Animation, 0, len(animations))
+	completed := make([]*Animation, 0)
+	for _, a := range animations {
+		if a.update(t, dt) {
+			completed = append(completed, a)
+		}
+		if !a.done {
+			t.animations = append(t.animations, a)
+		}
+	}
+	// callbacks may start further animations
+	for _, a := range completed {
+		if a.OnComplete != nil {
+			a.OnComplete(t)
+		}
+	}
+}
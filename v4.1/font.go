@@ -5,10 +5,10 @@
 package v41
 
 import (
+	"errors"
 	"github.com/4ydx/gltext"
 	"github.com/go-gl/gl/v4.1-core/gl"
 	"github.com/go-gl/mathgl/mgl32"
-	"image"
 )
 
 var fontVertexShaderSource string = `
@@ -16,22 +16,81 @@ var fontVertexShaderSource string = `
 
 uniform mat4 scale_matrix;
 uniform mat4 orthographic_matrix;
+uniform mat4 model_matrix;
 uniform vec2 final_position;
 
-in vec4 centered_position;
-in vec2 uv;
+// world space text replaces the orthographic projection with the caller's matrices
+uniform bool world;
+uniform bool billboard;
+uniform mat4 world_model;
+uniform mat4 world_view;
+uniform mat4 world_projection;
+uniform float pixels_per_unit;
+
+// per glyph effects: seconds of animation and the amplitude, speed and spread of the effects
+uniform float time;
+uniform vec3 effect;
+
+layout(location = 0) in vec4 centered_position;
+layout(location = 1) in vec2 uv;
+layout(location = 2) in vec4 color;
+layout(location = 3) in vec2 glyph; // rune index and effect
 
 out vec2 fragment_uv;
+out vec4 fragment_vertex_color;
+
+const float TAU = 6.2831853;
+
+float random(vec2 seed) {
+  return fract(sin(dot(seed, vec2(12.9898, 78.233))) * 43758.5453);
+}
+
+vec3 hue(float h) {
+  return clamp(abs(mod(h * 6.0 + vec3(0.0, 4.0, 2.0), 6.0) - 3.0) - 1.0, 0.0, 1.0);
+}
 
 // The orthographic projection uses a lower left-hand point of (0,0)
 // 1) We center the text on screen.
-// 2) We perform othographic transformation and then scaling.
-// 3) We move the text to its final resting place.
+// 2) We rotate and transform it in pixels.
+// 3) We perform othographic transformation and then scaling.
+// 4) We move the text to its final resting place.
 // This is all pretty standard I would imagine, but it took me a bit to sort out what has to happen :P
+//
+// World space text is instead converted from pixels to world units after step 2 and, scaled,
+// projected by the caller's matrices.  Billboards are laid out in view space around the
+// model's origin so that they always face the camera.
 
 void main() {
   fragment_uv = uv;
-  vec4 scaled = scale_matrix * orthographic_matrix * centered_position;
+  fragment_vertex_color = color;
+
+  vec4 position = centered_position;
+  int kind = int(glyph.y + 0.5);
+  float phase = time * effect.y + glyph.x * effect.z;
+  if (kind == 1) {
+    // wave
+    position.y += effect.x * sin(TAU * phase);
+  } else if (kind == 2) {
+    // shake: a new random offset 20 times per cycle
+    float tick = floor(time * effect.y * 20.0);
+    position.xy += effect.x * (vec2(random(vec2(glyph.x, tick)), random(vec2(tick, glyph.x))) * 2.0 - 1.0);
+  } else if (kind == 3) {
+    // rainbow
+    fragment_vertex_color.rgb = hue(fract(phase));
+  }
+
+  if (world) {
+    vec4 units = scale_matrix * vec4((model_matrix * position).xy / pixels_per_unit, 0.0, 1.0);
+    if (billboard) {
+      vec4 center = world_view * world_model * vec4(0.0, 0.0, 0.0, 1.0);
+      gl_Position = world_projection * vec4(center.xyz + vec3(units.xy, 0.0), center.w);
+    } else {
+      gl_Position = world_projection * world_view * world_model * units;
+    }
+    return;
+  }
+
+  vec4 scaled = scale_matrix * orthographic_matrix * model_matrix * position;
   gl_Position = vec4(scaled.x + final_position.x, scaled.y + final_position.y, scaled.z, scaled.w);
 }
 ` + "\x00"
@@ -42,20 +101,63 @@ var fontFragmentShaderSource string = `
 uniform sampler2D fragment_texture;
 uniform float fadeout;
 uniform vec4 fragment_color_adjustment;
+uniform bool premultiplied;
 
 in vec2 fragment_uv;
+in vec4 fragment_vertex_color;
 out vec4 fragment_color;
 
 void main() {
-  vec4 color     = texture(fragment_texture, fragment_uv);
-  color.xyz      = fragment_color_adjustment.xyz;
-	color.w        = color.w - fadeout;
+  // quads with negative texture coordinates, such as underlines, are solid
+  float coverage = 1.0;
+  if (fragment_uv.x >= 0.0) {
+    coverage = texture(fragment_texture, fragment_uv).w;
+  }
+  vec4 color     = fragment_vertex_color;
+  color.xyz      = color.xyz * fragment_color_adjustment.xyz;
+  color.w        = max(color.w * coverage * fragment_color_adjustment.w - fadeout, 0.0);
+  if (premultiplied) {
+    color.xyz *= color.w;
+  }
   fragment_color = color;
 }
 ` + "\x00"
 
+// MipmapMode selects how the mipmaps of a font texture are created.
+type MipmapMode uint8
+
+const (
+	// MipmapNone samples the full size texture only.
+	MipmapNone MipmapMode = iota
+	// MipmapGenerate lets the driver create the mipmaps with glGenerateMipmap.
+	MipmapGenerate
+	// MipmapDownsample creates the mipmaps on the cpu with gltext.MipmapChain.
+	MipmapDownsample
+)
+
+// FontOptions control how the glyph texture of a font is sampled.  The zero
+// value matches NewFont: linear filtering without mipmaps.
+type FontOptions struct {
+	// Mipmaps keeps text drawn below its baked size from shimmering.  The
+	// levels are limited to those allowed by the padding of the sprite sheet,
+	// see gltext.MaxMipmapLevel.  Fonts need a padding of at least 2 texels,
+	// NewFontWithOptions returns an error otherwise.
+	Mipmaps MipmapMode
+
+	// MinFilter and MagFilter are texture filters such as gl.NEAREST.  Zero
+	// selects gl.LINEAR, or gl.LINEAR_MIPMAP_LINEAR when minifying a
+	// mipmapped texture.
+	MinFilter int32
+	MagFilter int32
+
+	// Anisotropy above one enables anisotropic filtering, which keeps text
+	// viewed at an angle sharp.  It is clamped to the driver's maximum.
+	Anisotropy float32
+}
+
 type Font struct {
 	Config         *gltext.FontConfig // Character set for this font.
+	Fallbacks      []*Font            // Consulted in order for runes missing from Config.
 	textureID      uint32             // Holds the glyph texture id.
 	maxGlyphWidth  int                // Largest glyph width.
 	maxGlyphHeight int                // Largest glyph height.
@@ -64,6 +166,8 @@ type Font struct {
 	// attributes
 	centeredPositionAttribute uint32 // vertex centered_position required for scaling around the orthographic projections center
 	uvAttribute               uint32 // texture position
+	vertexColorAttribute      uint32 // color of the run a glyph belongs to
+	glyphAttribute            uint32 // rune index and effect of the run a glyph belongs to
 
 	// The final screen position post-scaling
 	finalPositionUniform int32
@@ -72,13 +176,27 @@ type Font struct {
 	fragmentTextureUniform int32
 
 	// The desired color of the text
-	colorUniform   int32
-	fadeoutUniform int32
+	colorUniform         int32
+	fadeoutUniform       int32
+	premultipliedUniform int32
+
+	// Per glyph effects
+	timeUniform   int32
+	effectUniform int32
 
 	// View matrix
 	orthographicMatrixUniform int32
+	modelMatrixUniform        int32
 	OrthographicMatrix        mgl32.Mat4
 
+	// World space
+	worldUniform           int32
+	billboardUniform       int32
+	worldModelUniform      int32
+	worldViewUniform       int32
+	worldProjectionUniform int32
+	pixelsPerUnitUniform   int32
+
 	// Scale the resulting text
 	scaleMatrixUniform int32
 
@@ -96,15 +214,45 @@ func (f *Font) GetTextureHeight() float32 {
 	return f.textureHeight
 }
 
+// resolve returns the first font of the fallback chain holding a glyph for the
+// rune along with the glyph's index.  The index is -1 when no font has the rune.
+// Only the fallbacks of the receiver are consulted, not those of its fallbacks.
+func (f *Font) resolve(r rune) (*Font, rune) {
+	if index := f.Config.GlyphIndex(r); index >= 0 {
+		return f, index
+	}
+	for _, fallback := range f.Fallbacks {
+		if index := fallback.Config.GlyphIndex(r); index >= 0 {
+			return fallback, index
+		}
+	}
+	return f, -1
+}
+
+// HasRune returns true when the font or one of its fallbacks holds the rune.
+func (f *Font) HasRune(r rune) bool {
+	_, index := f.resolve(r)
+	return index >= 0
+}
+
 func NewFont(config *gltext.FontConfig) (f *Font, err error) {
+	return NewFontWithOptions(config, FontOptions{})
+}
+
+// NewFontWithOptions behaves like NewFont while allowing the sampling of the
+// glyph texture to be configured.
+func NewFontWithOptions(config *gltext.FontConfig, opts FontOptions) (f *Font, err error) {
 	if config == nil {
 		panic("Nil config")
 	}
 	f = &Font{}
 	f.Config = config
+	if opts.Mipmaps != MipmapNone && gltext.MaxMipmapLevel(config.Padding) == 0 {
+		return f, errors.New("Mipmaps require a font baked with a padding of at least 2 texels.")
+	}
 
-	// Resize image to next power-of-two.
-	config.Image = gltext.Pow2Image(config.Image).(*image.NRGBA)
+	// Convert the image to the configured format and resize it to the next power-of-two.
+	config.Image = gltext.Pow2Image(gltext.ConvertImage(config.Image, config.Format))
 	ib := config.Image.Bounds()
 
 	f.textureWidth = float32(ib.Dx())
@@ -127,22 +275,97 @@ func NewFont(config *gltext.FontConfig) (f *Font, err error) {
 		}
 	}
 
+	pix, format, err := gltext.ImagePixels(config.Image)
+	if err != nil {
+		return f, err
+	}
+
+	// single channel images are uploaded as GL_R8 and swizzled so that the
+	// shader sees (1, 1, 1, coverage) just as it would with an RGBA texture
+	internalFormat, pixelFormat := int32(gl.RGBA), uint32(gl.RGBA)
+	if format != gltext.ImageFormatNRGBA {
+		internalFormat, pixelFormat = gl.R8, gl.RED
+	}
+
+	minFilter, magFilter := opts.MinFilter, opts.MagFilter
+	if minFilter == 0 {
+		minFilter = gl.LINEAR
+		if opts.Mipmaps != MipmapNone {
+			minFilter = gl.LINEAR_MIPMAP_LINEAR
+		}
+	}
+	if magFilter == 0 {
+		magFilter = gl.LINEAR
+	}
+
 	// generate texture
 	gl.GenTextures(1, &f.textureID)
 	gl.BindTexture(gl.TEXTURE_2D, f.textureID)
-	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
-	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, minFilter)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, magFilter)
+	if opts.Anisotropy > 1 {
+		// left at zero by drivers lacking anisotropic filtering
+		var maxAnisotropy float32
+		gl.GetFloatv(gl.MAX_TEXTURE_MAX_ANISOTROPY, &maxAnisotropy)
+		if maxAnisotropy > 1 {
+			anisotropy := opts.Anisotropy
+			if anisotropy > maxAnisotropy {
+				anisotropy = maxAnisotropy
+			}
+			gl.TexParameterf(gl.TEXTURE_2D, gl.TEXTURE_MAX_ANISOTROPY, anisotropy)
+		}
+	}
+	if format != gltext.ImageFormatNRGBA {
+		swizzle := []int32{gl.ONE, gl.ONE, gl.ONE, gl.RED}
+		gl.TexParameteriv(gl.TEXTURE_2D, gl.TEXTURE_SWIZZLE_RGBA, &swizzle[0])
+	}
+	gl.PixelStorei(gl.UNPACK_ALIGNMENT, 1)
 	gl.TexImage2D(
 		gl.TEXTURE_2D,
 		0,
-		gl.RGBA,
+		internalFormat,
 		int32(ib.Dx()),
 		int32(ib.Dy()),
 		0,
-		gl.RGBA,
+		pixelFormat,
 		gl.UNSIGNED_BYTE,
-		gl.Ptr(config.Image.Pix),
+		gl.Ptr(pix),
 	)
+
+	// the smaller levels stop before neighbouring glyphs would blend
+	maxLevel := gltext.MaxMipmapLevel(config.Padding)
+	switch opts.Mipmaps {
+	case MipmapNone:
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAX_LEVEL, 0)
+	case MipmapGenerate:
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAX_LEVEL, int32(maxLevel))
+		gl.GenerateMipmap(gl.TEXTURE_2D)
+	case MipmapDownsample:
+		levels, err := gltext.MipmapChain(config.Image, maxLevel)
+		if err != nil {
+			return f, err
+		}
+		for i, level := range levels {
+			pix, _, err := gltext.ImagePixels(level)
+			if err != nil {
+				return f, err
+			}
+			lb := level.Bounds()
+			gl.TexImage2D(
+				gl.TEXTURE_2D,
+				int32(i+1),
+				internalFormat,
+				int32(lb.Dx()),
+				int32(lb.Dy()),
+				0,
+				pixelFormat,
+				gl.UNSIGNED_BYTE,
+				gl.Ptr(pix),
+			)
+		}
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAX_LEVEL, int32(len(levels)))
+	}
+	gl.PixelStorei(gl.UNPACK_ALIGNMENT, 4)
 	gl.BindTexture(gl.TEXTURE_2D, 0)
 
 	// create shader program and define attributes and uniforms
@@ -154,14 +377,26 @@ func NewFont(config *gltext.FontConfig) (f *Font, err error) {
 	// attributes
 	f.centeredPositionAttribute = uint32(gl.GetAttribLocation(f.program, gl.Str("centered_position\x00")))
 	f.uvAttribute = uint32(gl.GetAttribLocation(f.program, gl.Str("uv\x00")))
+	f.vertexColorAttribute = uint32(gl.GetAttribLocation(f.program, gl.Str("color\x00")))
+	f.glyphAttribute = uint32(gl.GetAttribLocation(f.program, gl.Str("glyph\x00")))
 
 	// uniforms
 	f.finalPositionUniform = gl.GetUniformLocation(f.program, gl.Str("final_position\x00"))
 	f.orthographicMatrixUniform = gl.GetUniformLocation(f.program, gl.Str("orthographic_matrix\x00"))
 	f.scaleMatrixUniform = gl.GetUniformLocation(f.program, gl.Str("scale_matrix\x00"))
+	f.modelMatrixUniform = gl.GetUniformLocation(f.program, gl.Str("model_matrix\x00"))
+	f.worldUniform = gl.GetUniformLocation(f.program, gl.Str("world\x00"))
+	f.billboardUniform = gl.GetUniformLocation(f.program, gl.Str("billboard\x00"))
+	f.worldModelUniform = gl.GetUniformLocation(f.program, gl.Str("world_model\x00"))
+	f.worldViewUniform = gl.GetUniformLocation(f.program, gl.Str("world_view\x00"))
+	f.worldProjectionUniform = gl.GetUniformLocation(f.program, gl.Str("world_projection\x00"))
+	f.pixelsPerUnitUniform = gl.GetUniformLocation(f.program, gl.Str("pixels_per_unit\x00"))
 	f.fragmentTextureUniform = gl.GetUniformLocation(f.program, gl.Str("fragment_texture\x00"))
 	f.colorUniform = gl.GetUniformLocation(f.program, gl.Str("fragment_color_adjustment\x00"))
 	f.fadeoutUniform = gl.GetUniformLocation(f.program, gl.Str("fadeout\x00"))
+	f.premultipliedUniform = gl.GetUniformLocation(f.program, gl.Str("premultiplied\x00"))
+	f.timeUniform = gl.GetUniformLocation(f.program, gl.Str("time\x00"))
+	f.effectUniform = gl.GetUniformLocation(f.program, gl.Str("effect\x00"))
 
 	return f, nil
 }
@@ -172,6 +407,9 @@ func (f *Font) ResizeWindow(width float32, height float32) {
 	f.OrthographicMatrix = mgl32.Ortho2D(-f.WindowWidth/2, f.WindowWidth/2, -f.WindowHeight/2, f.WindowHeight/2)
 }
 
+// Release deletes the glyph texture and shader program of the font.
 func (f *Font) Release() {
 	gl.DeleteTextures(1, &f.textureID)
+	gl.DeleteProgram(f.program)
+	f.textureID, f.program = 0, 0
 }
@@ -9,8 +9,14 @@ import (
 	"github.com/4ydx/gltext"
 	"github.com/go-gl/gl/v4.1-core/gl"
 	"github.com/go-gl/mathgl/mgl32"
+	"sort"
+	"unicode/utf8"
 )
 
+// vertexStride is the number of floats per vertex: position, uv, rgba color,
+// the index of the rune within String and the effect of its run
+const vertexStride = 10
+
 // CharacterSide shows which side of a character is
 // clicked
 type CharacterSide int
@@ -25,11 +31,20 @@ const (
 type Text struct {
 	Font *Font
 
+	// fonts drawing the [b], [i] and combined styles of SetMarkup; nil draws them with Font
+	BoldFont       *Font
+	ItalicFont     *Font
+	BoldItalicFont *Font
+
 	// final position on screen
 	finalPosition mgl32.Vec2
 
-	// text color
-	color mgl32.Vec3
+	// text color, rgba
+	color mgl32.Vec4
+
+	// PremultipliedAlpha outputs colors multiplied by their alpha and blends them with
+	// (ONE, ONE_MINUS_SRC_ALPHA), matching renderers that composite in premultiplied space.
+	PremultipliedAlpha bool
 
 	// scaling the text
 	Scale       float32
@@ -37,7 +52,7 @@ type Text struct {
 	ScaleMax    float32
 	scaleMatrix mgl32.Mat4
 
-	// Fadeout reduces alpha
+	// Fadeout reduces alpha once per frame, the FadeOut animation does so over time
 	FadeOutBegun      bool
 	FadeOutFrameCount float32 // number of frames since drawing began
 	FadeOutPerFrame   float32 // smaller value takes more time
@@ -72,10 +87,66 @@ type Text struct {
 
 	String      string
 	CharSpacing []float32
+
+	// glyphs grouped by the font texture they are drawn from
+	batches   []textBatch
+	quadCount int32
+
+	// rich text takes its colors from its runs rather than from SetColor
+	rich     bool
+	runs     []Run
+	gradient *Gradient
+
+	// animations and the typewriter advanced by Update
+	animations []*Animation
+	typewriter *Typewriter
+
+	// EffectParams tunes the effects of the runs, animated by Update
+	EffectParams EffectParams
+	effectTime   float32
+
+	// rotation and affine transform around the pivot, see ModelMatrix
+	rotation  float32
+	transform *mgl32.Mat3
+	pivot     mgl32.Vec2
+
+	// placement in a 3D scene, nil for screen space text
+	world *WorldSpace
+
+	// layout along a path, see SetPath
+	path                *gltext.Path
+	pathOffset          float32
+	pathGlyphs          []pathGlyph
+	pathBottom, pathTop float32 // vertical extent of the straight layout
+}
+
+// textBatch holds the glyphs of a Text that are drawn from a single font texture.
+type textBatch struct {
+	font      *Font
+	eboOffset int     // first ebo index of the batch
+	quads     []int32 // vbo quad holding each glyph
+	runes     []int   // position of each glyph within String, ascending
+}
+
+// visible returns the number of glyphs of the batch found within the first runeCount runes.
+func (b *textBatch) visible(runeCount int) int {
+	return sort.SearchInts(b.runes, runeCount)
+}
+
+// batch returns the batch for the given font, creating it when necessary.
+func (t *Text) batch(f *Font) *textBatch {
+	for i := range t.batches {
+		if t.batches[i].font == f {
+			return &t.batches[i]
+		}
+	}
+	t.batches = append(t.batches, textBatch{font: f})
+	return &t.batches[len(t.batches)-1]
 }
 
+// GetLength returns the number of runes laid out by SetString.
 func (t *Text) GetLength() int {
-	return t.eboIndexCount / 6
+	return len(t.CharSpacing)
 }
 
 // NewText creates a new text object with scaling boundaries
@@ -84,6 +155,7 @@ func (t *Text) GetLength() int {
 func NewText(f *Font, scaleMin, scaleMax float32) (t *Text) {
 	t = &Text{}
 	t.Font = f
+	t.color = mgl32.Vec4{0, 0, 0, 1}
 
 	// text hover values
 	// "resting state" of a text object is the min scale
@@ -93,7 +165,9 @@ func NewText(f *Font, scaleMin, scaleMax float32) (t *Text) {
 
 	// stride of the buffered data
 	xy_count := int32(2)
-	stride := xy_count + int32(2)
+	uv_count := int32(2)
+	rgba_count := int32(4)
+	stride := int32(vertexStride)
 
 	gl.GenVertexArrays(1, &t.vao)
 	gl.GenBuffers(1, &t.vbo)
@@ -131,6 +205,26 @@ func NewText(f *Font, scaleMin, scaleMax float32) (t *Text) {
 		gl.PtrOffset(int(glfloat_size*xy_count)),
 	)
 
+	gl.EnableVertexAttribArray(t.Font.vertexColorAttribute)
+	gl.VertexAttribPointer(
+		t.Font.vertexColorAttribute,
+		4,
+		gl.FLOAT,
+		false,
+		glfloat_size*stride,
+		gl.PtrOffset(int(glfloat_size*(xy_count+uv_count))),
+	)
+
+	gl.EnableVertexAttribArray(t.Font.glyphAttribute)
+	gl.VertexAttribPointer(
+		t.Font.glyphAttribute,
+		2,
+		gl.FLOAT,
+		false,
+		glfloat_size*stride,
+		gl.PtrOffset(int(glfloat_size*(xy_count+uv_count+rgba_count))),
+	)
+
 	// ebo
 	gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, t.ebo)
 
@@ -171,34 +265,73 @@ func (t *Text) AddScale(s float32) bool {
 	return true
 }
 
+// SetColor sets the color of the text, keeping its alpha.
 func (t *Text) SetColor(color mgl32.Vec3) {
+	t.color = color.Vec4(t.color[3])
+}
+
+// SetAlpha sets the opacity of the text from 0, invisible, to 1.  Unlike the color,
+// it also applies to rich text and gradients.
+func (t *Text) SetAlpha(alpha float32) {
+	t.color[3] = alpha
+}
+
+// SetRGBA sets the color and opacity of the text.
+func (t *Text) SetRGBA(color mgl32.Vec4) {
 	t.color = color
 }
 
+// GetColor returns the color and opacity of the text.
+func (t *Text) GetColor() mgl32.Vec4 {
+	return t.color
+}
+
 // SetString performs creates new vbo and ebo objects as well as to perform all
 // binding required for displaying text to screen
 func (t *Text) SetString(fs string, argv ...interface{}) {
-	indices := []rune(fmt.Sprintf(fs, argv...))
-	if t.MaxRuneCount > 0 && len(indices) > t.MaxRuneCount+1 {
-		indices = indices[0:t.MaxRuneCount]
+	t.rich = false
+	t.stopTypewriter()
+	t.setRuns([]Run{{Text: fmt.Sprintf(fs, argv...), Color: mgl32.Vec4{1, 1, 1, 1}}})
+}
+
+// setRuns lays out the runs, limited to MaxRuneCount runes, and uploads the result.
+func (t *Text) setRuns(runs []Run) {
+	count := 0
+	for _, run := range runs {
+		count += utf8.RuneCountInString(run.Text)
+	}
+	if t.MaxRuneCount > 0 && count > t.MaxRuneCount+1 {
+		runs = truncateRuns(runs, t.MaxRuneCount)
+		count = t.MaxRuneCount
+	}
+	t.runs = runs
+	t.String = ""
+	for _, run := range runs {
+		t.String += run.Text
 	}
-	t.String = string(indices)
 
 	// ebo, vbo data
 	glfloat_size := int32(4)
 
-	t.vboIndexCount = len(indices) * 4 * 2 * 2 // 4 indexes per rune (containing 2 position + 2 texture)
-	t.eboIndexCount = len(indices) * 6         // each rune requires 6 triangle indices for a quad
-	t.RuneCount = len(indices)
-	t.vboData = make([]float32, t.vboIndexCount, t.vboIndexCount)
-	t.eboData = make([]int32, t.eboIndexCount, t.eboIndexCount)
+	// most runes require a single quad: 4 vertices (containing 2 position + 2 texture + 4 color)
+	// and 6 triangle indices.  replacement hex boxes and underlines need a few more.
+	t.RuneCount = count
+	t.vboData = make([]float32, 0, count*4*vertexStride)
+	t.eboData = make([]int32, 0, count*6)
 
 	// generate the basic vbo data and bounding box
 	// center the vbo data around the orthographic (0,0) point
 	t.X1 = gltext.Point{0, 0}
 	t.X2 = gltext.Point{0, 0}
-	t.makeBufferData(indices)
-	t.centerTheData(t.getLowerLeft())
+	t.makeBufferData(runs)
+	if t.path != nil {
+		// text along a path keeps the path's coordinates
+		t.centerTheData(gltext.Point{})
+	} else {
+		t.centerTheData(t.getLowerLeft())
+	}
+	t.vboIndexCount = len(t.vboData)
+	t.eboIndexCount = len(t.eboData)
 
 	if gltext.IsDebug {
 		prefix := gltext.DebugPrefix()
@@ -206,7 +339,7 @@ func (t *Text) SetString(fs string, argv ...interface{}) {
 		fmt.Printf("%s text vbo data\n%v\n", prefix, t.vboData)
 		fmt.Printf("%s text ebo data\n%v\n", prefix, t.eboData)
 	}
-	if len(indices) > 0 {
+	if t.eboIndexCount > 0 && t.vbo != 0 {
 		// in the event that we have no data to draw dont bother here
 		gl.BindVertexArray(t.vao)
 		gl.BindBuffer(gl.ARRAY_BUFFER, t.vbo)
@@ -225,17 +358,19 @@ func (t *Text) SetString(fs string, argv ...interface{}) {
 	// SetString can be called at anytime.  we want to make sure that if the user is updating the text,
 	// the previous position will be maintained
 	t.SetPosition(t.Position)
+
+	if t.typewriter != nil {
+		t.typewriter.relayout()
+	}
 }
 
 // The block of text is positioned around the center of the screen, which in this case must
 // be considered (0,0).  This is necessary for orthographic projection and scaling to work
 // well together.  If the text is *not* at (0,0), then scaling doesnt produce a direct zoom effect.
+// Glyph cells reach below the baseline, so the box does not necessarily start at (0,0).
 func (t *Text) getLowerLeft() (lowerLeft gltext.Point) {
-	lineWidthHalf := (t.X2.X - t.X1.X) / 2
-	lineHeightHalf := (t.X2.Y - t.X1.Y) / 2
-
-	lowerLeft.X = -lineWidthHalf
-	lowerLeft.Y = -lineHeightHalf
+	lowerLeft.X = -(t.X1.X + t.X2.X) / 2
+	lowerLeft.Y = -(t.X1.Y + t.X2.Y) / 2
 	return
 }
 
@@ -252,7 +387,19 @@ func (t *Text) SetPosition(v mgl32.Vec2) {
 	t.Position = v
 }
 
+// GetBoundingBox returns the lower left and upper right corners of the text's bounding box
+// in screen space.  For rotated, transformed or scaled text it is the axis aligned box
+// around GetOrientedBoundingBox.
 func (t *Text) GetBoundingBox() (X1, X2 gltext.Point) {
+	if t.transformed() {
+		corners := t.GetOrientedBoundingBox()
+		X1, X2 = corners[0], corners[0]
+		for _, c := range corners[1:] {
+			X1.X, X1.Y = min32(X1.X, c.X), min32(X1.Y, c.Y)
+			X2.X, X2.Y = max32(X2.X, c.X), max32(X2.Y, c.Y)
+		}
+		return
+	}
 	x, y := t.Position.X(), t.Position.Y()
 	X1.X = t.X1.X + x
 	X1.Y = t.X1.Y + y
@@ -273,33 +420,78 @@ func (t *Text) Draw() {
 		}
 	}
 
-	gl.UseProgram(t.Font.program)
-
-	gl.ActiveTexture(gl.TEXTURE0)
-	gl.BindTexture(gl.TEXTURE_2D, t.Font.textureID)
-
-	// uniforms
-	gl.Uniform1i(t.Font.fragmentTextureUniform, 0)
-	gl.Uniform1f(t.Font.fadeoutUniform, t.FadeOutPerFrame*t.FadeOutFrameCount)
-	gl.Uniform4fv(t.Font.colorUniform, 1, &t.color[0])
-	gl.Uniform2fv(t.Font.finalPositionUniform, 1, &t.finalPosition[0])
-	gl.UniformMatrix4fv(t.Font.orthographicMatrixUniform, 1, false, &t.Font.OrthographicMatrix[0])
-	gl.UniformMatrix4fv(t.Font.scaleMatrixUniform, 1, false, &t.scaleMatrix[0])
-
-	// draw
-	drawCount := int32(t.RuneCount * 6)
-	if drawCount > int32(t.eboIndexCount) {
-		drawCount = int32(t.eboIndexCount)
-	}
-	if drawCount <= 0 {
+	if t.RuneCount <= 0 || len(t.batches) == 0 {
 		return
 	}
 	gl.Enable(gl.BLEND)
-	gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
+	if t.PremultipliedAlpha {
+		gl.BlendFunc(gl.ONE, gl.ONE_MINUS_SRC_ALPHA)
+	} else {
+		gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
+	}
+	restoreDepth := t.beginDepth()
 	gl.BindVertexArray(t.vao)
-	gl.DrawElements(gl.TRIANGLES, drawCount, gl.UNSIGNED_INT, nil)
+
+	// one draw call per font texture, each limited to the glyphs within RuneCount
+	for i := range t.batches {
+		b := &t.batches[i]
+		drawCount := int32(b.visible(t.RuneCount) * 6)
+		if drawCount <= 0 {
+			continue
+		}
+		t.useFont(b.font)
+		gl.DrawElements(gl.TRIANGLES, drawCount, gl.UNSIGNED_INT, gl.PtrOffset(b.eboOffset*4))
+	}
 	gl.BindVertexArray(0)
 	gl.Disable(gl.BLEND)
+	restoreDepth()
+}
+
+// useFont binds the program and texture of the font and sets the uniforms of the text.
+// The text's own font provides the orthographic matrix so that fallback fonts line up.
+func (t *Text) useFont(f *Font) {
+	gl.UseProgram(f.program)
+
+	gl.ActiveTexture(gl.TEXTURE0)
+	gl.BindTexture(gl.TEXTURE_2D, f.textureID)
+
+	// uniforms
+	gl.Uniform1i(f.fragmentTextureUniform, 0)
+	gl.Uniform1f(f.fadeoutUniform, t.FadeOutPerFrame*t.FadeOutFrameCount)
+	color := t.color
+	if t.rich || t.gradient != nil {
+		// the vertices carry the colors, only the opacity applies
+		color = mgl32.Vec4{1, 1, 1, t.color[3]}
+	}
+	gl.Uniform4fv(f.colorUniform, 1, &color[0])
+	premultiplied := int32(0)
+	if t.PremultipliedAlpha {
+		premultiplied = 1
+	}
+	gl.Uniform1i(f.premultipliedUniform, premultiplied)
+	effect := t.effectParams()
+	gl.Uniform1f(f.timeUniform, t.effectTime)
+	gl.Uniform3f(f.effectUniform, effect.Amplitude, effect.Speed, effect.Spread)
+	gl.Uniform2fv(f.finalPositionUniform, 1, &t.finalPosition[0])
+	gl.UniformMatrix4fv(f.orthographicMatrixUniform, 1, false, &t.Font.OrthographicMatrix[0])
+	gl.UniformMatrix4fv(f.scaleMatrixUniform, 1, false, &t.scaleMatrix[0])
+	model := t.modelMatrix4()
+	gl.UniformMatrix4fv(f.modelMatrixUniform, 1, false, &model[0])
+	t.useWorld(f)
+}
+
+func min32(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max32(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
 }
 
 func (t *Text) BeginFadeOut() {
@@ -323,31 +515,9 @@ func (t *Text) Hide() {
 // as named, the function centers the text around the orthographic center of the screen
 // expected to only be called within SetString
 func (t *Text) centerTheData(lowerLeft gltext.Point) (err error) {
-	length := len(t.vboData)
-	for index := 0; index < length; {
-		// index (0,0)
+	for index := 0; index < len(t.vboData); index += vertexStride {
 		t.vboData[index] += lowerLeft.X
-		index++
-		t.vboData[index] += lowerLeft.Y
-		index += 3 // skip texture data
-
-		// index (1,0)
-		t.vboData[index] += lowerLeft.X
-		index++
-		t.vboData[index] += lowerLeft.Y
-		index += 3
-
-		// index (1,1)
-		t.vboData[index] += lowerLeft.X
-		index++
-		t.vboData[index] += lowerLeft.Y
-		index += 3
-
-		// index (0,1)
-		t.vboData[index] += lowerLeft.X
-		index++
-		t.vboData[index] += lowerLeft.Y
-		index += 3
+		t.vboData[index+1] += lowerLeft.Y
 	}
 
 	// update bounding box so that it is centered around (0,0)
@@ -383,10 +553,15 @@ func (t *Text) PrintCharSpacing() {
 
 // ClickedCharacter should only be called after a bounding box hit is confirmed because
 // it does not check y-axis values at all.  Returns the index and side of the char clicked.
+// Rotated or transformed text and text along a path require HitTest.
 func (t *Text) ClickedCharacter(xPos, offset float64) (index int, side CharacterSide) {
 	// transform from screen coordinates to... window coordinates?
-	xPos = xPos - float64(t.Font.WindowWidth/2) - offset
+	return t.characterAt(xPos - float64(t.Font.WindowWidth/2) - offset)
+}
 
+// characterAt returns the index and side of the character found at x within the
+// centered layout.
+func (t *Text) characterAt(xPos float64) (index int, side CharacterSide) {
 	// could do a binary search...
 	at := float64(t.X1.X)
 	for i, cs := range t.CharSpacing {
@@ -419,96 +594,167 @@ func (t *Text) CharPosition(index int) float64 {
 	return at
 }
 
+// HasRune returns true when the text's font or one of its fallbacks holds the rune.
 func (t *Text) HasRune(r rune) bool {
-	for _, runes := range t.Font.Config.RuneRanges {
-		if r >= runes.Low && r <= runes.High {
-			return true
-		}
-	}
-	return false
+	return t.Font.HasRune(r)
 }
 
-// makeBufferData positions quads for drawing the text in the indices parameter using glyph dimensions
+// makeBufferData positions quads for drawing the runs using glyph dimensions
 // it also generates the bounding box (which needs to later be centered around (0,0))
-// expected to only be called by SetString
-func (t *Text) makeBufferData(indices []rune) {
-	glyphs := t.Font.Config.Glyphs
-
-	vboIndex := 0
-	eboIndex := 0
+// expected to only be called by setRuns
+//
+// Each rune is drawn with the first font of its run's fallback chain that holds it, or with the
+// replacement glyph of the run's font.  Glyphs of every run are placed on a shared baseline at
+// y = 0 and grouped into one batch per font texture.  Every rune receives a CharSpacing entry,
+// even when there is nothing to draw, so that CharSpacing lines up with the runes of String.
+func (t *Text) makeBufferData(runs []Run) {
 	lineX := float32(0)
-	eboOffset := int32(0)
 
-	t.CharSpacing = make([]float32, 0)
-	for i, r := range indices {
-		glyphIndex := t.Font.Config.RuneRanges.GetGlyphIndex(r)
-		if glyphIndex >= 0 {
+	count := 0
+	for _, run := range runs {
+		count += utf8.RuneCountInString(run.Text)
+	}
+	t.CharSpacing = make([]float32, 0, count)
+	t.vboData = t.vboData[:0]
+	t.batches = t.batches[:0]
+	t.quadCount = 0
+
+	i := -1
+	for _, run := range runs {
+		runFont := run.Font
+		if runFont == nil {
+			runFont = t.Font
+		}
+		scale := run.Scale
+		if scale == 0 {
+			scale = 1
+		}
+		style := run
+		style.Scale = scale
+		for _, r := range run.Text {
+			i++
+			font, glyphIndex := runFont.resolve(r)
+			var glyph gltext.Glyph
+			switch {
+			case glyphIndex >= 0:
+				glyph = font.Config.Glyphs[glyphIndex]
+			case runFont.Config.Replacement != nil:
+				font, glyph = runFont, *runFont.Config.Replacement
+			default:
+				t.CharSpacing = append(t.CharSpacing, 0)
+				continue
+			}
 			if gltext.IsDebug {
 				prefix := gltext.DebugPrefix()
-				fmt.Printf("%s png index %3d: %s rune %+v line at %f", prefix, glyphIndex, string(r), glyphs[glyphIndex], lineX)
+				fmt.Printf("%s png index %3d: %s rune %+v line at %f", prefix, glyphIndex, string(r), glyph, lineX)
 			}
-			advance := float32(glyphs[glyphIndex].Advance)
 
 			// Originally the glyph Width was used, but that results in quads that overlap one another.
-			vw := float32(glyphs[glyphIndex].Advance)
-			vh := float32(glyphs[glyphIndex].Height)
+			advance := float32(glyph.Advance) * scale
+			vh := float32(glyph.Height) * scale
+
+			// the glyph's cell is shifted so that its baseline lies on y = 0
+			bottom := -float32(font.Config.Descent(glyph)) * scale
+			top := bottom + vh
 
 			// used to determine which character inside of the text was clicked
 			t.CharSpacing = append(t.CharSpacing, advance)
 
-			// variable width characters will produce a bounding box that is just
-			// a bit too long on the right-hand side unless we trim off the excess
-			// when processing the right-most character
-			trim := float32(0)
-			if i == len(indices)-1 {
-				trim = vw - advance
+			// the bounding box value X2 is being expanded as characters are added
+			// and covers the lowest and highest glyph cells
+			t.X2.X = lineX + advance
+			t.extendBoundingBox(bottom, top)
+			t.addQuad(font, i, glyph, lineX, bottom, style)
+
+			// a hex box shows the code point of the missing rune inside the box
+			if glyphIndex < 0 && font.Config.HexBox != nil {
+				for _, digit := range font.Config.HexBox.Layout(r) {
+					digitTop := top - float32(digit.Y)*scale
+					digitHeight := float32(digit.Glyph.Height) * scale
+					t.addQuad(font, i, digit.Glyph, lineX+float32(digit.X)*scale, digitTop-digitHeight, style)
+				}
 			}
-			tP1, tP2 := glyphs[glyphIndex].GetTexturePositions(t.Font)
 
-			// counter-clockwise quad
-			// the bounding box value X2 is being expanded as characters are added
+			if run.Underline {
+				y, thickness := underline(runFont, scale)
+				t.extendBoundingBox(y, y+thickness)
+				t.addSolidQuad(font, i, lineX, y, advance, thickness, style)
+			}
 
-			// index (0,0)
-			t.vboData[vboIndex] = lineX // position
-			vboIndex++
-			t.vboData[vboIndex] = 0
-			vboIndex++
-			t.vboData[vboIndex] = tP1.X // texture uv
-			vboIndex++
-			t.vboData[vboIndex] = tP2.Y
-			vboIndex++
-
-			// index (1,0) - expanding X2
-			t.vboData[vboIndex], t.X2.X = lineX+vw, lineX+vw-trim
-			vboIndex++
-			t.vboData[vboIndex] = 0
-			vboIndex++
-			t.vboData[vboIndex] = tP2.X
-			vboIndex++
-			t.vboData[vboIndex] = tP2.Y
-			vboIndex++
-
-			// index (1,1) - expanding X2
-			t.vboData[vboIndex] = lineX + vw
-			vboIndex++
-			t.vboData[vboIndex], t.X2.Y = vh, vh
-			vboIndex++
-			t.vboData[vboIndex] = tP2.X
-			vboIndex++
-			t.vboData[vboIndex] = tP1.Y
-			vboIndex++
-
-			// index (0,1)
-			t.vboData[vboIndex] = lineX
-			vboIndex++
-			t.vboData[vboIndex] = vh
-			vboIndex++
-			t.vboData[vboIndex] = tP1.X
-			vboIndex++
-			t.vboData[vboIndex] = tP1.Y
-			vboIndex++
-
-			// ebo data
+			// shift to the right
+			lineX += advance
+			if gltext.IsDebug {
+				fmt.Printf("-> %f\n", lineX)
+			}
+		}
+	}
+	t.applyPath()
+	t.applyGradient()
+	t.makeElementData()
+	if gltext.IsDebug {
+		gltext.PrintVertices(t.vboData, vertexStride, t.Font.GetTextureHeight(), t.Font.GetTextureWidth())
+	}
+	return
+}
+
+// extendBoundingBox grows the bounding box to cover the vertical span from bottom to top.
+func (t *Text) extendBoundingBox(bottom, top float32) {
+	if t.quadCount == 0 || bottom < t.X1.Y {
+		t.X1.Y = bottom
+	}
+	if t.quadCount == 0 || top > t.X2.Y {
+		t.X2.Y = top
+	}
+}
+
+// addQuad appends the vbo data for a glyph whose lower left corner is placed at (x, y)
+// and records it in the batch of the font.  index is the position of the rune within String
+// and the run provides its scale, which must not be 0, color and effect.
+func (t *Text) addQuad(font *Font, index int, glyph gltext.Glyph, x, y float32, run Run) {
+	vw := float32(glyph.Advance) * run.Scale
+	vh := float32(glyph.Height) * run.Scale
+	tP1, tP2 := glyph.GetTexturePositionsInset(font, font.Config.TextureInset())
+	t.appendQuad(font, index, x, y, vw, vh, tP1, tP2, run)
+}
+
+// addSolidQuad appends a quad that is filled with the color rather than textured.
+// The shader recognizes such quads by their negative texture coordinates.
+func (t *Text) addSolidQuad(font *Font, index int, x, y, w, h float32, run Run) {
+	solid := gltext.Point{X: -1, Y: -1}
+	t.appendQuad(font, index, x, y, w, h, solid, solid, run)
+}
+
+func (t *Text) appendQuad(font *Font, index int, x, y, vw, vh float32, tP1, tP2 gltext.Point, run Run) {
+	r, g, b, a := run.Color[0], run.Color[1], run.Color[2], run.Color[3]
+	n, e := float32(index), float32(run.Effect)
+
+	// counter-clockwise quad: position followed by texture uv, color, rune index and effect
+	t.vboData = append(t.vboData,
+		x, y, tP1.X, tP2.Y, r, g, b, a, n, e, // index (0,0)
+		x+vw, y, tP2.X, tP2.Y, r, g, b, a, n, e, // index (1,0)
+		x+vw, y+vh, tP2.X, tP1.Y, r, g, b, a, n, e, // index (1,1)
+		x, y+vh, tP1.X, tP1.Y, r, g, b, a, n, e, // index (0,1)
+	)
+
+	batch := t.batch(font)
+	batch.quads = append(batch.quads, t.quadCount)
+	batch.runes = append(batch.runes, index)
+	t.quadCount++
+}
+
+// makeElementData fills the ebo so that the quads of each batch are contiguous
+func (t *Text) makeElementData() {
+	if count := int(t.quadCount) * 6; cap(t.eboData) < count {
+		t.eboData = make([]int32, count)
+	} else {
+		t.eboData = t.eboData[:count]
+	}
+	eboIndex := 0
+	for i := range t.batches {
+		b := &t.batches[i]
+		b.eboOffset = eboIndex
+		for _, quad := range b.quads {
+			eboOffset := quad * 4
 			t.eboData[eboIndex] = 0 + eboOffset
 			eboIndex++
 			t.eboData[eboIndex] = 1 + eboOffset
@@ -522,17 +768,6 @@ func (t *Text) makeBufferData(indices []rune) {
 			eboIndex++
 			t.eboData[eboIndex] = 3 + eboOffset
 			eboIndex++
-			eboOffset += 4
-
-			// shift to the right
-			lineX += advance
-			if gltext.IsDebug {
-				fmt.Printf("-> %f\n", lineX)
-			}
 		}
 	}
-	if gltext.IsDebug {
-		gltext.PrintVBO(t.vboData, t.Font.GetTextureHeight(), t.Font.GetTextureWidth())
-	}
-	return
 }
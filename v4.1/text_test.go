@@ -7,6 +7,7 @@ package v41
 import (
 	"github.com/4ydx/gltext"
 	"github.com/go-gl/mathgl/mgl32"
+	"math"
 	"testing"
 )
 
@@ -77,3 +78,568 @@ func TestBoundingBox(t *testing.T) {
 		t.Error(x2)
 	}
 }
+
+func TestMipmapsNeedPadding(t *testing.T) {
+	config := &gltext.FontConfig{Padding: 1}
+	if _, err := NewFontWithOptions(config, FontOptions{Mipmaps: MipmapGenerate}); err == nil {
+		t.Error("Expecting an error for mipmaps of a sprite sheet without enough padding")
+	}
+}
+
+// newTestFont creates a font without any opengl resources, one glyph per rune of runeRange
+func newTestFont(runeRange gltext.RuneRange, advance, height, baseline int) *Font {
+	f := &Font{textureWidth: 256, textureHeight: 256}
+	f.Config = &gltext.FontConfig{Baseline: baseline}
+	f.Config.RuneRanges = gltext.RuneRanges{runeRange}
+	for i := 0; i < f.Config.RuneRanges.RuneCount(); i++ {
+		f.Config.Glyphs = append(f.Config.Glyphs, gltext.Glyph{X: i * advance, Width: advance, Height: height, Advance: advance})
+	}
+	return f
+}
+
+// layout runs the cpu side of SetString
+func layout(text *Text, s string) {
+	layoutRuns(text, Run{Text: s, Color: mgl32.Vec4{1, 1, 1, 1}})
+}
+
+// layoutRuns runs the cpu side of SetRuns
+func layoutRuns(text *Text, runs ...Run) {
+	text.String = ""
+	for _, run := range runs {
+		text.String += run.Text
+	}
+	text.RuneCount = len([]rune(text.String))
+	text.X1, text.X2 = gltext.Point{}, gltext.Point{}
+	text.makeBufferData(runs)
+}
+
+// vertex returns the data of a corner of a quad: (0,0), (1,0), (1,1) and (0,1) in that order
+func vertex(text *Text, quad, corner int) []float32 {
+	at := (quad*4 + corner) * vertexStride
+	return text.vboData[at : at+vertexStride]
+}
+
+func vertexColor(text *Text, quad, corner int) mgl32.Vec4 {
+	v := vertex(text, quad, corner)
+	return mgl32.Vec4{v[4], v[5], v[6], v[7]}
+}
+
+func TestCenterTheData(t *testing.T) {
+	// the cells reach 4 pixels below the baseline
+	f := newTestFont(gltext.RuneRange{Low: 'a', High: 'c'}, 10, 20, 16)
+	text := &Text{Font: f}
+	text.SetString("abc")
+	if text.X1 != (gltext.Point{X: -15, Y: -10}) || text.X2 != (gltext.Point{X: 15, Y: 10}) {
+		t.Error("The bounding box should be centered around (0,0)", text.X1, text.X2)
+	}
+	if v := vertex(text, 0, 0); v[0] != -15 || v[1] != -10 {
+		t.Error("The vertices should move along with the bounding box", v)
+	}
+}
+
+func TestFallbackFonts(t *testing.T) {
+	primary := newTestFont(gltext.RuneRange{Low: 'a', High: 'c'}, 10, 20, 16)
+	fallback := newTestFont(gltext.RuneRange{Low: 'あ', High: 'あ'}, 20, 30, 24)
+	primary.Fallbacks = []*Font{fallback}
+
+	text := &Text{Font: primary}
+	if !text.HasRune('あ') || text.HasRune('z') {
+		t.Error("HasRune should consult the fallbacks")
+	}
+	layout(text, "aあb")
+
+	if len(text.batches) != 2 {
+		t.Fatal("Expecting a batch per font", len(text.batches))
+	}
+	if b := text.batches[1]; b.font != fallback || b.quads[0] != 1 || b.runes[0] != 1 {
+		t.Error("Unexpected fallback batch", b)
+	}
+	if n := text.batches[0].visible(2); n != 1 {
+		t.Error("Only 'a' of the primary font is within the first two runes", n)
+	}
+	if text.batches[1].eboOffset != 12 || text.eboData[12] != 4 {
+		t.Error("Fallback glyphs should follow the primary glyphs in the ebo")
+	}
+
+	// baselines line up: the primary cells reach 4 below it, the fallback cell 6
+	if y := vertex(text, 1, 0)[1]; y != -6 {
+		t.Error("Unexpected fallback bottom", y)
+	}
+	if text.X1.Y != -6 || text.X2.Y != 24 {
+		t.Error("Unexpected bounding box", text.X1, text.X2)
+	}
+	if x := vertex(text, 2, 0)[0]; x != 30 {
+		t.Error("'b' should follow the fallback advance", x)
+	}
+}
+
+func TestReplacementGlyph(t *testing.T) {
+	f := newTestFont(gltext.RuneRange{Low: 'a', High: 'c'}, 10, 20, 16)
+	text := &Text{Font: f}
+
+	// without a replacement glyph missing runes still keep their place
+	layout(text, "aあb")
+	if len(text.CharSpacing) != 3 || text.CharSpacing[1] != 0 {
+		t.Error("Expecting a CharSpacing entry per rune", text.CharSpacing)
+	}
+	if text.quadCount != 2 || text.batches[0].runes[1] != 2 {
+		t.Error("Only 'a' and 'b' should be drawn", text.batches[0].runes)
+	}
+
+	// a hex box draws the box followed by four digits for runes in the bmp
+	f.Config.Replacement = &gltext.Glyph{X: 100, Height: 20, Advance: 16}
+	f.Config.HexBox = &gltext.HexBox{Top: 1, Unit: 1, Digits: make(gltext.Charset, 16)}
+	for i := range f.Config.HexBox.Digits {
+		f.Config.HexBox.Digits[i] = gltext.Glyph{X: 200 + i*3, Width: 3, Height: 5, Advance: 3}
+	}
+	layout(text, "aあb")
+	if text.quadCount != 7 || text.CharSpacing[1] != 16 {
+		t.Error("Unexpected hex box layout", text.quadCount, text.CharSpacing)
+	}
+	if runes := text.batches[0].runes; runes[1] != 1 || runes[5] != 1 || runes[6] != 2 {
+		t.Error("Hex box quads should belong to the missing rune", runes)
+	}
+	// the first digit '3' sits inside the box: top of cell 16, box top 1, border 2
+	if x, y := vertex(text, 2, 0)[0], vertex(text, 2, 0)[1]; x != 14 || y != 16-3-5 {
+		t.Error("Unexpected digit position", x, y)
+	}
+}
+
+func TestRichText(t *testing.T) {
+	primary := newTestFont(gltext.RuneRange{Low: 'a', High: 'c'}, 10, 20, 16)
+	other := newTestFont(gltext.RuneRange{Low: 'a', High: 'c'}, 20, 30, 24)
+	red := mgl32.Vec4{1, 0, 0, 1}
+	blue := mgl32.Vec4{0, 0, 1, 0.5}
+
+	text := &Text{Font: primary}
+	layoutRuns(text,
+		Run{Text: "ab", Color: red},
+		Run{Text: "c", Color: blue, Scale: 2, Underline: true},
+		Run{Text: "a", Font: other},
+	)
+	if text.String != "abca" || len(text.CharSpacing) != 4 {
+		t.Fatal("Runs should be laid out as a single string", text.String, text.CharSpacing)
+	}
+
+	// a, b, c, the underline of c and the a of the other font
+	if text.quadCount != 5 || len(text.batches) != 2 {
+		t.Fatal("Expecting a batch per font", text.quadCount, len(text.batches))
+	}
+	if runes := text.batches[0].runes; runes[2] != 2 || runes[3] != 2 {
+		t.Error("The underline should belong to its rune", runes)
+	}
+	for corner := 0; corner < 4; corner++ {
+		if c := vertexColor(text, 1, corner); c != red {
+			t.Error("Unexpected color of b", c)
+		}
+		if c := vertexColor(text, 2, corner); c != blue {
+			t.Error("Unexpected color of c", c)
+		}
+	}
+
+	// the scaled c starts after a and b and is twice as large, sharing the baseline
+	if c0, c2 := vertex(text, 2, 0), vertex(text, 2, 2); c0[0] != 20 || c2[0] != 40 || c0[1] != -8 || c2[1] != 32 {
+		t.Error("Unexpected scaled quad", c0, c2)
+	}
+	if text.CharSpacing[2] != 20 {
+		t.Error("Scaled runs should advance further", text.CharSpacing)
+	}
+
+	// the underline is solid and spans the advance below the baseline
+	u0, u2 := vertex(text, 3, 0), vertex(text, 3, 2)
+	if u0[2] >= 0 || u0[0] != 20 || u2[0] != 40 || u2[1] >= 0 || u0[1] >= u2[1] {
+		t.Error("Unexpected underline", u0, u2)
+	}
+
+	// the other font's glyph shares the baseline and follows the scaled c
+	if a := vertex(text, 4, 0); a[0] != 40 || a[1] != -6 {
+		t.Error("Unexpected position of the other font", a)
+	}
+	if text.X1.Y != -8 || text.X2.Y != 32 || text.X2.X != 60 {
+		t.Error("Unexpected bounding box", text.X1, text.X2)
+	}
+
+	// every vertex knows its rune and the effect of its run
+	layoutRuns(text, Run{Text: "a"}, Run{Text: "b", Effect: EffectWave, Underline: true})
+	if v := vertex(text, 1, 3); v[8] != 1 || v[9] != float32(EffectWave) {
+		t.Error("Unexpected rune index or effect", v)
+	}
+	if v := vertex(text, 2, 0); v[8] != 1 || v[9] != float32(EffectWave) {
+		t.Error("Underlines should follow the effect of their glyph", v)
+	}
+	if v := vertex(text, 0, 0); v[8] != 0 || v[9] != float32(EffectNone) {
+		t.Error("Runs without an effect should have none", v)
+	}
+
+	if runs := truncateRuns([]Run{{Text: "ab"}, {Text: "cあd"}, {Text: "e"}}, 4); len(runs) != 2 || runs[1].Text != "cあ" {
+		t.Error("Unexpected truncation", runs)
+	}
+}
+
+func TestMarkupRuns(t *testing.T) {
+	bold := newTestFont(gltext.RuneRange{Low: 'a', High: 'c'}, 12, 20, 16)
+	text := &Text{Font: newTestFont(gltext.RuneRange{Low: 'a', High: 'c'}, 10, 20, 16), BoldFont: bold}
+	text.SetColor(mgl32.Vec3{0, 1, 0})
+
+	runs, err := text.markupRuns("a[b]b[/b][u][color=red][rainbow]c[/rainbow][/color][/u]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 3 {
+		t.Fatal("Unexpected runs", runs)
+	}
+	if runs[0].Color != (mgl32.Vec4{0, 1, 0, 1}) || runs[0].Font != nil || runs[0].Scale != 1 {
+		t.Error("Unstyled text should use the text's color and font", runs[0])
+	}
+	if runs[1].Font != bold || runs[2].Font != nil {
+		t.Error("Bold runs should use the bold font", runs[1], runs[2])
+	}
+	if !runs[2].Underline || runs[2].Color != (mgl32.Vec4{1, 0, 0, 1}) || runs[2].Effect != EffectRainbow {
+		t.Error("Unexpected underlined run", runs[2])
+	}
+	if text.styleFont(true, true) != bold || text.styleFont(false, true) != nil {
+		t.Error("Missing styles should fall back")
+	}
+	if _, err := text.markupRuns("[b]a"); err == nil {
+		t.Error("Expecting an error for an unclosed tag")
+	}
+}
+
+func TestGradient(t *testing.T) {
+	f := newTestFont(gltext.RuneRange{Low: 'a', High: 'c'}, 10, 20, 16)
+	text := &Text{Font: f}
+	red, blue := mgl32.Vec4{1, 0, 0, 1}, mgl32.Vec4{0, 0, 1, 1}
+
+	// the cells of "ab" span 20 by 20 pixels: 4 below and 16 above the baseline
+	g := HorizontalGradient(red, blue)
+	text.gradient = &g
+	layout(text, "ab")
+	if c := vertexColor(text, 0, 0); c != red {
+		t.Error("The left edge should be red", c)
+	}
+	if c := vertexColor(text, 1, 0); c != (mgl32.Vec4{0.5, 0, 0.5, 1}) {
+		t.Error("The middle should be blended", c)
+	}
+	if c := vertexColor(text, 1, 2); c != blue {
+		t.Error("The right edge should be blue", c)
+	}
+
+	g = VerticalGradient(red, blue)
+	g.PerGlyph = true
+	text.gradient = &g
+	layoutRuns(text, Run{Text: "ab", Color: mgl32.Vec4{1, 1, 1, 0.5}})
+	for quad := 0; quad < 2; quad++ {
+		if c := vertexColor(text, quad, 0); c != (mgl32.Vec4{0, 0, 1, 0.5}) {
+			t.Error("Every glyph should be blue at its bottom, times the run color", c)
+		}
+		if c := vertexColor(text, quad, 3); c != (mgl32.Vec4{1, 0, 0, 0.5}) {
+			t.Error("Every glyph should be red at its top, times the run color", c)
+		}
+	}
+}
+
+func TestColorAlpha(t *testing.T) {
+	text := &Text{}
+	text.SetRGBA(mgl32.Vec4{1, 0, 0, 0.5})
+	text.SetColor(mgl32.Vec3{0, 1, 0})
+	if c := text.GetColor(); c != (mgl32.Vec4{0, 1, 0, 0.5}) {
+		t.Error("SetColor should keep the alpha", c)
+	}
+	text.SetAlpha(0.25)
+	if c := text.GetColor(); c != (mgl32.Vec4{0, 1, 0, 0.25}) {
+		t.Error("SetAlpha should keep the color", c)
+	}
+}
+
+func TestAnimation(t *testing.T) {
+	text := &Text{Font: &Font{WindowWidth: 200, WindowHeight: 100}, ScaleMin: 1, ScaleMax: 2, Scale: 1}
+	text.SetRGBA(mgl32.Vec4{1, 1, 1, 1})
+
+	completed := 0
+	fade := FadeOut(2)
+	fade.Delay = 1
+	fade.OnComplete = func(t *Text) {
+		completed++
+		t.Animate(MoveTo(mgl32.Vec2{10, 20}, 1))
+	}
+	text.Animate(fade)
+
+	text.Update(1.5)
+	if a := text.GetColor()[3]; a != 0.75 {
+		t.Error("The fade should start after its delay", a)
+	}
+	text.Update(2)
+	if a := text.GetColor()[3]; a != 0 || completed != 1 || !fade.Done() {
+		t.Error("The fade should have completed", a, completed)
+	}
+	if !text.Animating() {
+		t.Fatal("The callback should have started a move")
+	}
+	text.Update(0.5)
+	if p := text.Position; p != (mgl32.Vec2{5, 10}) {
+		t.Error("Unexpected position halfway", p)
+	}
+	text.Update(0.5)
+	if p := text.Position; p != (mgl32.Vec2{10, 20}) || text.Animating() || completed != 1 {
+		t.Error("Unexpected position at the end", p)
+	}
+
+	// a pulse grows during the first half of its period and shrinks during the second
+	pulse := Pulse(2)
+	pulse.Easing = gltext.Linear
+	text.Animate(pulse, ColorTo(mgl32.Vec4{0, 0, 0, 1}, 4))
+	text.Update(0.5)
+	if text.Scale != 1.5 {
+		t.Error("Unexpected scale while growing", text.Scale)
+	}
+	text.Update(1)
+	if text.Scale != 1.5 {
+		t.Error("Unexpected scale while shrinking", text.Scale)
+	}
+	if c := text.GetColor(); c != (mgl32.Vec4{0.625, 0.625, 0.625, 0.375}) {
+		t.Error("Unexpected color tween", c)
+	}
+	text.Update(2.5)
+	if pulse.Done() || text.Scale != 1.0 {
+		t.Error("Pulses should repeat", text.Scale)
+	}
+	text.StopAnimations()
+	if !pulse.Done() || text.Animating() {
+		t.Error("Animations should stop")
+	}
+}
+
+func TestTypewriter(t *testing.T) {
+	f := newTestFont(gltext.RuneRange{Low: ' ', High: 'z'}, 10, 20, 16)
+	text := &Text{Font: f}
+	layoutRuns(text, Run{Text: "ab. c", Color: mgl32.Vec4{1, 1, 1, 0.5}})
+
+	completed := 0
+	w := text.Typewrite(10)
+	w.FadeDuration = 0.2
+	w.OnComplete = func(*Text) { completed++ }
+	if text.RuneCount != 0 {
+		t.Error("The text should start hidden", text.RuneCount)
+	}
+
+	// a appears at once and b after 0.1 seconds
+	text.Update(0.15)
+	if text.RuneCount != 2 {
+		t.Error("Unexpected number of revealed runes", text.RuneCount)
+	}
+	if a, b := vertexColor(text, 0, 0)[3], vertexColor(text, 1, 2)[3]; !mgl32.FloatEqualThreshold(a, 0.5*0.75, 1e-6) || !mgl32.FloatEqualThreshold(b, 0.5*0.25, 1e-6) {
+		t.Error("Runes should fade in", a, b)
+	}
+
+	// the period is followed by a pause of 0.3 seconds
+	text.Update(0.35)
+	if text.RuneCount != 3 || vertexColor(text, 2, 0)[3] != 0.5 {
+		t.Error("The pause should hold back the space", text.RuneCount, vertexColor(text, 2, 0))
+	}
+	text.Update(0.2)
+	if text.RuneCount != 4 || w.Complete() {
+		t.Error("The space should follow the pause", text.RuneCount)
+	}
+	text.Update(0.3)
+	if text.RuneCount != 5 || !w.Complete() || completed != 1 {
+		t.Error("The typewriter should have completed", text.RuneCount, completed)
+	}
+	select {
+	case <-w.Done():
+	default:
+		t.Error("Done should be closed")
+	}
+	for quad := 0; quad < 5; quad++ {
+		if a := vertexColor(text, quad, 1)[3]; a != 0.5 {
+			t.Error("Every rune should end at its own alpha", quad, a)
+		}
+	}
+
+	w = text.Typewrite(1)
+	w.Skip()
+	if text.RuneCount != 5 || !w.Complete() {
+		t.Error("Skip should reveal every rune", text.RuneCount)
+	}
+}
+
+func TestTypewriterRelayout(t *testing.T) {
+	f := newTestFont(gltext.RuneRange{Low: ' ', High: 'z'}, 10, 20, 16)
+	text := &Text{Font: f}
+	text.SetRuns(Run{Text: "abc", Color: mgl32.Vec4{1, 1, 1, 1}})
+
+	completed := 0
+	w := text.Typewrite(10)
+	w.FadeDuration = 0.2
+	w.OnComplete = func(*Text) { completed++ }
+	text.Update(0.15)
+
+	// a gradient lays the text out again without stopping the reveal
+	text.SetGradient(VerticalGradient(mgl32.Vec4{1, 1, 1, 0.5}, mgl32.Vec4{1, 1, 1, 0.5}))
+	if text.RuneCount != 2 || w.Complete() {
+		t.Error("A gradient should keep the reveal going", text.RuneCount)
+	}
+	if a, b := vertexColor(text, 0, 0)[3], vertexColor(text, 1, 0)[3]; !mgl32.FloatEqualThreshold(a, 0.5*0.75, 1e-6) || !mgl32.FloatEqualThreshold(b, 0.5*0.25, 1e-6) {
+		t.Error("Runes should keep fading in with the gradient's alpha", a, b)
+	}
+	text.Update(0.5)
+	if text.RuneCount != 3 || !w.Complete() || completed != 1 {
+		t.Error("The typewriter should complete after a relayout", text.RuneCount, completed)
+	}
+	if a := vertexColor(text, 2, 0)[3]; a != 0.5 {
+		t.Error("The last rune should end at the gradient's alpha", a)
+	}
+
+	// a new string stops the typewriter and closes Done
+	w = text.Typewrite(10)
+	text.Update(0.05)
+	text.SetString("xyz")
+	select {
+	case <-w.Done():
+	default:
+		t.Error("Setting a new string should close Done")
+	}
+	if text.RuneCount != 3 || text.typewriter != nil {
+		t.Error("Setting a new string should show the whole text", text.RuneCount)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	text := &Text{Font: &Font{WindowWidth: 200, WindowHeight: 100}}
+	text.X1, text.X2 = gltext.Point{X: -20, Y: -5}, gltext.Point{X: 20, Y: 5}
+	text.CharSpacing = []float32{10, 10, 10, 10}
+	text.SetPosition(mgl32.Vec2{100, 50})
+
+	near := func(a, b gltext.Point) bool {
+		return mgl32.FloatEqualThreshold(a.X, b.X, 1e-4) && mgl32.FloatEqualThreshold(a.Y, b.Y, 1e-4)
+	}
+
+	// a quarter turn around the right end of the text
+	text.SetRotation(math.Pi / 2)
+	text.SetPivot(mgl32.Vec2{20, 0})
+	corners := text.GetOrientedBoundingBox()
+	if !near(corners[0], gltext.Point{X: 125, Y: 10}) || !near(corners[2], gltext.Point{X: 115, Y: 50}) {
+		t.Error("Unexpected oriented box", corners)
+	}
+	x1, x2 := text.GetBoundingBox()
+	if !near(x1, gltext.Point{X: 115, Y: 10}) || !near(x2, gltext.Point{X: 125, Y: 50}) {
+		t.Error("Unexpected bounding box of the rotated text", x1, x2)
+	}
+
+	// the first character now sits at the bottom, the last at the top
+	if index, side, inside := text.HitTest(120, 17); index != 0 || side != CSRight || !inside {
+		t.Error("Unexpected hit near the bottom", index, side, inside)
+	}
+	if index, _, inside := text.HitTest(120, 45); index != 3 || !inside {
+		t.Error("Unexpected hit near the top", index, inside)
+	}
+	if _, _, inside := text.HitTest(130, 45); inside {
+		t.Error("The point should lie outside of the text")
+	}
+
+	// transforms apply after the rotation
+	text.SetRotation(0)
+	text.SetPivot(mgl32.Vec2{})
+	text.SetTransform(mgl32.Scale2D(2, 1))
+	if x1, x2 := text.GetBoundingBox(); !near(x1, gltext.Point{X: 60, Y: 45}) || !near(x2, gltext.Point{X: 140, Y: 55}) {
+		t.Error("Unexpected bounding box of the scaled text", x1, x2)
+	}
+	if index, side, _ := text.HitTest(75, 50); index != 0 || side != CSRight {
+		t.Error("Unexpected hit of the scaled text", index, side)
+	}
+	text.ClearTransform()
+	if x1, _ := text.GetBoundingBox(); x1 != (gltext.Point{X: 80, Y: 45}) {
+		t.Error("Unexpected bounding box without a transform", x1)
+	}
+
+	// Scale applies after the rotation, around the text's origin
+	text.ScaleMin, text.ScaleMax = 1, 2
+	text.SetScale(2)
+	text.SetRotation(math.Pi / 2)
+	corners = text.GetOrientedBoundingBox()
+	if !near(corners[0], gltext.Point{X: 110, Y: 10}) || !near(corners[2], gltext.Point{X: 90, Y: 90}) {
+		t.Error("Unexpected oriented box of the scaled text", corners)
+	}
+	if index, side, inside := text.HitTest(95, 25); index != 0 || side != CSRight || !inside {
+		t.Error("Unexpected hit of the scaled text", index, side, inside)
+	}
+	if _, _, inside := text.HitTest(115, 50); inside {
+		t.Error("The point should lie outside of the scaled text")
+	}
+	text.SetRotation(0)
+	if x1, x2 := text.GetBoundingBox(); !near(x1, gltext.Point{X: 60, Y: 40}) || !near(x2, gltext.Point{X: 140, Y: 60}) {
+		t.Error("Unexpected bounding box of the scaled text", x1, x2)
+	}
+}
+
+func TestWorldSpace(t *testing.T) {
+	text := &Text{Font: &Font{WindowWidth: 200, WindowHeight: 100}, Scale: 1}
+	text.X1, text.X2 = gltext.Point{X: -20, Y: -5}, gltext.Point{X: 20, Y: 5}
+	if text.WorldSpace() != nil || text.WorldCorners() != ([4]mgl32.Vec3{}) {
+		t.Error("Text should start in screen space")
+	}
+
+	camera := &Camera{View: mgl32.Ident4(), Projection: mgl32.Ident4()}
+	text.SetWorldSpace(WorldSpace{Camera: camera, Model: mgl32.Translate3D(1, 2, 3), PixelsPerUnit: 10})
+	corners := text.WorldCorners()
+	if corners[0] != (mgl32.Vec3{-1, 1.5, 3}) || corners[2] != (mgl32.Vec3{3, 2.5, 3}) {
+		t.Error("Unexpected world corners", corners)
+	}
+
+	// the model's rotation turns the text, the text's own rotation turns it within its plane
+	text.WorldSpace().Model = mgl32.HomogRotate3DY(math.Pi / 2)
+	text.WorldSpace().PixelsPerUnit = 0
+	text.SetRotation(math.Pi)
+	corners = text.WorldCorners()
+	if c := corners[0]; !c.ApproxEqualThreshold(mgl32.Vec3{0, 0.05, -0.2}, 1e-4) {
+		t.Error("Unexpected rotated corner", corners)
+	}
+
+	// a zero model places the text at the origin
+	text.SetRotation(0)
+	text.SetWorldSpace(WorldSpace{Camera: camera, PixelsPerUnit: 10})
+	if corners := text.WorldCorners(); corners[0] != (mgl32.Vec3{-2, -0.5, 0}) || corners[2] != (mgl32.Vec3{2, 0.5, 0}) {
+		t.Error("A zero model should be taken as the identity", corners)
+	}
+	text.ClearWorldSpace()
+	if text.WorldSpace() != nil {
+		t.Error("Text should be back in screen space")
+	}
+}
+
+func TestTextPath(t *testing.T) {
+	f := newTestFont(gltext.RuneRange{Low: 'a', High: 'c'}, 10, 20, 16)
+	text := &Text{Font: &Font{WindowWidth: 200, WindowHeight: 100}}
+	text.Font = f
+
+	// up the y axis: every rune is turned a quarter counter-clockwise
+	text.path = gltext.Polyline(gltext.Point{X: 0, Y: 0}, gltext.Point{X: 0, Y: 100})
+	text.pathOffset = 10
+	layout(text, "abc")
+	if text.Advance() != 30 {
+		t.Error("Unexpected advance", text.Advance())
+	}
+	near := func(v []float32, x, y float32) bool {
+		return mgl32.FloatEqualThreshold(v[0], x, 1e-4) && mgl32.FloatEqualThreshold(v[1], y, 1e-4)
+	}
+	// the lower left of 'b' lies 4 below the baseline at distance 20 along the path
+	if v := vertex(text, 1, 0); !near(v, 4, 20) {
+		t.Error("Unexpected lower left corner of b", v)
+	}
+	if v := vertex(text, 1, 2); !near(v, -16, 30) {
+		t.Error("Unexpected upper right corner of b", v)
+	}
+	if !near([]float32{text.X1.X, text.X1.Y}, -16, 10) || !near([]float32{text.X2.X, text.X2.Y}, 4, 40) {
+		t.Error("Unexpected bounding box", text.X1, text.X2)
+	}
+
+	if index, side, inside := text.pathCharacterAt(-5, 27); index != 1 || side != CSRight || !inside {
+		t.Error("Unexpected hit", index, side, inside)
+	}
+	if index, _, inside := text.pathCharacterAt(-30, 38); index != 2 || inside {
+		t.Error("Points outside of every rune should find the closest", index, inside)
+	}
+	text.Position = mgl32.Vec2{50, 0}
+	if index, _, inside := text.HitTest(45, 13); index != 0 || !inside {
+		t.Error("HitTest should find runes along the path", index, inside)
+	}
+}
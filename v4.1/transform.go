@@ -0,0 +1,111 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package v41
+
+import (
+	"github.com/4ydx/gltext"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// SetRotation rotates the text counter-clockwise by the angle in radians around its pivot.
+func (t *Text) SetRotation(radians float32) {
+	t.rotation = radians
+}
+
+// GetRotation returns the angle set by SetRotation.
+func (t *Text) GetRotation() float32 {
+	return t.rotation
+}
+
+// SetTransform applies a 2D affine transform in pixels, such as a skew or a non uniform
+// scale, around the pivot after the rotation.
+func (t *Text) SetTransform(m mgl32.Mat3) {
+	t.transform = &m
+}
+
+// ClearTransform removes the transform set by SetTransform.
+func (t *Text) ClearTransform() {
+	t.transform = nil
+}
+
+// SetPivot sets the point that rotations and transforms keep in place.  It is given in
+// pixels relative to the center of the text's bounding box, (0, 0) by default.  Text
+// along a path is not centered, so its pivot is relative to the origin of the path.
+func (t *Text) SetPivot(pivot mgl32.Vec2) {
+	t.pivot = pivot
+}
+
+// ModelMatrix returns the rotation and transform of the text around its pivot as a 2D
+// affine matrix in pixels.  It is applied before the text is moved to its Position.
+func (t *Text) ModelMatrix() mgl32.Mat3 {
+	m := mgl32.Translate2D(t.pivot.X(), t.pivot.Y())
+	if t.transform != nil {
+		m = m.Mul3(*t.transform)
+	}
+	if t.rotation != 0 {
+		m = m.Mul3(mgl32.HomogRotate2D(t.rotation))
+	}
+	return m.Mul3(mgl32.Translate2D(-t.pivot.X(), -t.pivot.Y()))
+}
+
+// modelMatrix4 returns ModelMatrix for the shader.
+func (t *Text) modelMatrix4() mgl32.Mat4 {
+	m := t.ModelMatrix()
+	return mgl32.Mat4{
+		m[0], m[1], 0, 0,
+		m[3], m[4], 0, 0,
+		0, 0, 1, 0,
+		m[6], m[7], 0, 1,
+	}
+}
+
+// transformed reports whether the text is rotated, transformed or scaled.
+func (t *Text) transformed() bool {
+	return t.rotation != 0 || t.transform != nil || t.scale() != 1
+}
+
+// scale returns the Scale applied by the shader after ModelMatrix, treating an unset
+// Scale as 1.
+func (t *Text) scale() float32 {
+	if t.Scale == 0 {
+		return 1
+	}
+	return t.Scale
+}
+
+// GetOrientedBoundingBox returns the corners of the rotated, transformed and scaled
+// bounding box in the coordinates of Position: lower left, lower right, upper right and
+// upper left before the transformation.
+func (t *Text) GetOrientedBoundingBox() (corners [4]gltext.Point) {
+	m := t.ModelMatrix()
+	scale := t.scale()
+	local := [4]mgl32.Vec2{{t.X1.X, t.X1.Y}, {t.X2.X, t.X1.Y}, {t.X2.X, t.X2.Y}, {t.X1.X, t.X2.Y}}
+	for i, p := range local {
+		q := m.Mul3x1(p.Vec3(1)).Mul(scale)
+		corners[i] = gltext.Point{X: q.X() + t.Position.X(), Y: q.Y() + t.Position.Y()}
+	}
+	return
+}
+
+// HitTest maps a point given in the coordinates of Position, pixels from the center of the
+// window with y pointing up, into the text by inverting its transform.  It returns the
+// index and side of the character at the point and whether the point lies within the
+// bounding box.  Unlike ClickedCharacter, it takes rotations, transforms and Scale into
+// account.  For text along a path, the point must lie within the cell of a rune to be
+// inside.
+func (t *Text) HitTest(x, y float32) (index int, side CharacterSide, inside bool) {
+	m := t.ModelMatrix()
+	scale := t.scale()
+	if m.Det() == 0 {
+		return -1, CSUnknown, false
+	}
+	p := m.Inv().Mul3x1(mgl32.Vec3{(x - t.Position.X()) / scale, (y - t.Position.Y()) / scale, 1})
+	if t.path != nil {
+		return t.pathCharacterAt(p.X(), p.Y())
+	}
+	inside = p.X() >= t.X1.X && p.X() <= t.X2.X && p.Y() >= t.X1.Y && p.Y() <= t.X2.Y
+	index, side = t.characterAt(float64(p.X()))
+	return index, side, inside
+}
@@ -0,0 +1,141 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package v41
+
+import (
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// DefaultPixelsPerUnit is used by world space text that leaves PixelsPerUnit at zero.
+const DefaultPixelsPerUnit = 100
+
+// Camera holds the view and projection matrices of a 3D scene.  Texts placed in the
+// world keep a pointer to their camera, so updating it once per frame moves every
+// label along with it.
+type Camera struct {
+	View       mgl32.Mat4
+	Projection mgl32.Mat4
+}
+
+// WorldSpace places a Text in a 3D scene instead of on the screen.
+type WorldSpace struct {
+	Camera *Camera
+
+	// Model positions the text in the world.  The text is laid out in its xy plane
+	// around the origin, facing +z.  Billboards only use its translation.  The zero
+	// matrix is taken as the identity.
+	Model mgl32.Mat4
+
+	// PixelsPerUnit converts the pixels of the baked font to world units.
+	PixelsPerUnit float32
+
+	// DepthTest hides the text behind closer geometry.  The text does not write to the
+	// depth buffer, so it should be drawn after the opaque parts of the scene.
+	DepthTest bool
+
+	// Billboard turns the text to face the camera whatever the model's rotation.
+	Billboard bool
+}
+
+// SetWorldSpace draws the text in a 3D scene.  Position has no effect in world space,
+// while Scale, rotations and transforms still apply around the text's pivot.
+func (t *Text) SetWorldSpace(w WorldSpace) {
+	t.world = &w
+}
+
+// WorldSpace returns the world placement of the text or nil for screen space text.
+// Changes to it, such as a new model matrix, apply with the next Draw.
+func (t *Text) WorldSpace() *WorldSpace {
+	return t.world
+}
+
+// ClearWorldSpace draws the text on the screen again.
+func (t *Text) ClearWorldSpace() {
+	t.world = nil
+}
+
+// pixelsPerUnit returns the conversion from pixels to world units.
+func (w *WorldSpace) pixelsPerUnit() float32 {
+	if w.PixelsPerUnit <= 0 {
+		return DefaultPixelsPerUnit
+	}
+	return w.PixelsPerUnit
+}
+
+// model returns Model, or the identity when it is left at zero.
+func (w *WorldSpace) model() mgl32.Mat4 {
+	if w.Model == (mgl32.Mat4{}) {
+		return mgl32.Ident4()
+	}
+	return w.Model
+}
+
+// useWorld sets the world space uniforms of the font's program.
+func (t *Text) useWorld(f *Font) {
+	w := t.world
+	if w == nil || w.Camera == nil {
+		gl.Uniform1i(f.worldUniform, 0)
+		return
+	}
+	billboard := int32(0)
+	if w.Billboard {
+		billboard = 1
+	}
+	model := w.model()
+	gl.Uniform1i(f.worldUniform, 1)
+	gl.Uniform1i(f.billboardUniform, billboard)
+	gl.UniformMatrix4fv(f.worldModelUniform, 1, false, &model[0])
+	gl.UniformMatrix4fv(f.worldViewUniform, 1, false, &w.Camera.View[0])
+	gl.UniformMatrix4fv(f.worldProjectionUniform, 1, false, &w.Camera.Projection[0])
+	gl.Uniform1f(f.pixelsPerUnitUniform, w.pixelsPerUnit())
+}
+
+// beginDepth sets up depth testing for drawing world space text and returns a function
+// that restores the previous state.  Screen space text leaves the state alone.
+func (t *Text) beginDepth() func() {
+	if t.world == nil || t.world.Camera == nil {
+		return func() {}
+	}
+	enabled := gl.IsEnabled(gl.DEPTH_TEST)
+	var mask bool
+	gl.GetBooleanv(gl.DEPTH_WRITEMASK, &mask)
+	test := t.world.DepthTest
+	if test {
+		gl.Enable(gl.DEPTH_TEST)
+		gl.DepthMask(false)
+	} else {
+		gl.Disable(gl.DEPTH_TEST)
+	}
+	return func() {
+		if test {
+			gl.DepthMask(mask)
+		}
+		if enabled {
+			gl.Enable(gl.DEPTH_TEST)
+		} else {
+			gl.Disable(gl.DEPTH_TEST)
+		}
+	}
+}
+
+// WorldCorners returns the corners of the text's bounding box in world coordinates:
+// lower left, lower right, upper right and upper left.  Billboards are not turned to
+// the camera.
+func (t *Text) WorldCorners() (corners [4]mgl32.Vec3) {
+	if t.world == nil {
+		return
+	}
+	ppu := t.world.pixelsPerUnit()
+	model := t.world.model()
+	m := t.ModelMatrix()
+	local := [4]mgl32.Vec2{{t.X1.X, t.X1.Y}, {t.X2.X, t.X1.Y}, {t.X2.X, t.X2.Y}, {t.X1.X, t.X2.Y}}
+	scale := t.scale()
+	for i, p := range local {
+		q := m.Mul3x1(p.Vec3(1)).Mul(scale / ppu)
+		corners[i] = model.Mul4x1(mgl32.Vec4{q.X(), q.Y(), 0, 1}).Vec3()
+	}
+	return
+}
@@ -0,0 +1,49 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package v45
+
+// Effect animates the glyphs of a run individually in the vertex shader.  Each
+// glyph is offset in time by its position within the text.
+type Effect int32
+
+const (
+	EffectNone    Effect = iota
+	EffectWave           // glyphs bob up and down in a wave travelling along the text
+	EffectShake          // glyphs jitter around their place
+	EffectRainbow        // glyph colors cycle through the hues, keeping their alpha
+)
+
+// effectNames are the names of the effects in markup.
+var effectNames = map[string]Effect{
+	"wave":    EffectWave,
+	"shake":   EffectShake,
+	"rainbow": EffectRainbow,
+}
+
+// EffectParams tunes the effects of a Text.  Zero values take those of
+// DefaultEffectParams.
+type EffectParams struct {
+	Amplitude float32 // pixels the wave and shake effects move glyphs by
+	Speed     float32 // cycles per second
+	Spread    float32 // phase difference between neighbouring glyphs, in cycles
+}
+
+// DefaultEffectParams are used for the parameters a Text leaves at zero.
+var DefaultEffectParams = EffectParams{Amplitude: 3, Speed: 1, Spread: 0.1}
+
+// effectParams returns the parameters of the text with defaults filled in.
+func (t *Text) effectParams() EffectParams {
+	p := t.EffectParams
+	if p.Amplitude == 0 {
+		p.Amplitude = DefaultEffectParams.Amplitude
+	}
+	if p.Speed == 0 {
+		p.Speed = DefaultEffectParams.Speed
+	}
+	if p.Spread == 0 {
+		p.Spread = DefaultEffectParams.Spread
+	}
+	return p
+}
@@ -0,0 +1,79 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package v45
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Gradient blends four corner colors across the text or across every glyph.
+// The colors multiply the colors of the runs, so white keeps them unchanged.
+type Gradient struct {
+	TopLeft     mgl32.Vec4
+	TopRight    mgl32.Vec4
+	BottomLeft  mgl32.Vec4
+	BottomRight mgl32.Vec4
+
+	// PerGlyph spans the gradient over each glyph's quad instead of the bounding box.
+	PerGlyph bool
+}
+
+// VerticalGradient blends from top to bottom.
+func VerticalGradient(top, bottom mgl32.Vec4) Gradient {
+	return Gradient{TopLeft: top, TopRight: top, BottomLeft: bottom, BottomRight: bottom}
+}
+
+// HorizontalGradient blends from left to right.
+func HorizontalGradient(left, right mgl32.Vec4) Gradient {
+	return Gradient{TopLeft: left, TopRight: right, BottomLeft: left, BottomRight: right}
+}
+
+// at returns the color at the relative position (s, u) where (0, 0) is the bottom left
+// and (1, 1) the top right corner.
+func (g *Gradient) at(s, u float32) mgl32.Vec4 {
+	bottom := g.BottomLeft.Mul(1 - s).Add(g.BottomRight.Mul(s))
+	top := g.TopLeft.Mul(1 - s).Add(g.TopRight.Mul(s))
+	return bottom.Mul(1 - u).Add(top.Mul(u))
+}
+
+// SetGradient colors the text with the gradient and lays it out again.  Like rich
+// text, the colors replace the one set by SetColor.
+func (t *Text) SetGradient(g Gradient) {
+	t.gradient = &g
+	t.setRuns(t.runs)
+}
+
+// ClearGradient removes the gradient set by SetGradient.
+func (t *Text) ClearGradient() {
+	t.gradient = nil
+	t.setRuns(t.runs)
+}
+
+// applyGradient multiplies the vertex colors with the gradient.  It expects the
+// bounding box of the uncentered vbo data.
+func (t *Text) applyGradient() {
+	if t.gradient == nil {
+		return
+	}
+	w, h := t.X2.X-t.X1.X, t.X2.Y-t.X1.Y
+	corners := [4][2]float32{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+	for index := 0; index < len(t.vboData); index += vertexStride {
+		v := t.vboData[index : index+vertexStride]
+		var s, u float32
+		if t.gradient.PerGlyph {
+			corner := corners[(index/vertexStride)%4]
+			s, u = corner[0], corner[1]
+		} else {
+			if w > 0 {
+				s = (v[0] - t.X1.X) / w
+			}
+			if h > 0 {
+				u = (v[1] - t.X1.Y) / h
+			}
+		}
+		c := t.gradient.at(s, u)
+		v[4], v[5], v[6], v[7] = v[4]*c[0], v[5]*c[1], v[6]*c[2], v[7]*c[3]
+	}
+}
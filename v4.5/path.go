@@ -0,0 +1,106 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package v45
+
+import (
+	"github.com/4ydx/gltext"
+	"math"
+)
+
+// pathGlyph is the placement of a rune along a path.
+type pathGlyph struct {
+	center  gltext.Point // point of the path below the middle of the rune
+	sin     float32      // of the tangent's angle
+	cos     float32
+	advance float32
+	mid     float32 // middle of the rune in the straight layout
+}
+
+// SetPath lays the text out along the path, starting offset pixels from its beginning,
+// and sets the runes again.  Each rune is centered on the path at the distance of its
+// middle and turned to the path's direction there.  The path is given in pixels
+// relative to Position and the text is not centered around it.
+//
+// To center the text on the path use an offset of (path.Length() - t.Advance()) / 2.
+func (t *Text) SetPath(path *gltext.Path, offset float32) {
+	t.path = path
+	t.pathOffset = offset
+	t.setRuns(t.runs)
+}
+
+// ClearPath lays the text out on a straight line again.
+func (t *Text) ClearPath() {
+	t.path = nil
+	t.setRuns(t.runs)
+}
+
+// Advance returns the sum of the advances of the runes, the length the text takes up
+// along a path.
+func (t *Text) Advance() (advance float32) {
+	for _, cs := range t.CharSpacing {
+		advance += cs
+	}
+	return
+}
+
+// applyPath moves the quads of every rune from the straight layout onto the path and
+// fits the bounding box around them.
+func (t *Text) applyPath() {
+	t.pathGlyphs = t.pathGlyphs[:0]
+	if t.path == nil {
+		return
+	}
+	t.pathBottom, t.pathTop = t.X1.Y, t.X2.Y
+	start := float32(0)
+	for _, advance := range t.CharSpacing {
+		center, angle := t.path.At(t.pathOffset + start + advance/2)
+		sin, cos := math.Sincos(float64(angle))
+		t.pathGlyphs = append(t.pathGlyphs, pathGlyph{
+			center: center, sin: float32(sin), cos: float32(cos), advance: advance, mid: start + advance/2,
+		})
+		start += advance
+	}
+
+	t.X1, t.X2 = gltext.Point{}, gltext.Point{}
+	for index := 0; index < len(t.vboData); index += vertexStride {
+		v := t.vboData[index : index+vertexStride]
+		g := t.pathGlyphs[int(v[8])]
+		x, y := v[0]-g.mid, v[1]
+		v[0] = g.center.X + x*g.cos - y*g.sin
+		v[1] = g.center.Y + x*g.sin + y*g.cos
+		if index == 0 {
+			t.X1 = gltext.Point{X: v[0], Y: v[1]}
+			t.X2 = t.X1
+		}
+		t.X1.X, t.X1.Y = min32(t.X1.X, v[0]), min32(t.X1.Y, v[1])
+		t.X2.X, t.X2.Y = max32(t.X2.X, v[0]), max32(t.X2.Y, v[1])
+	}
+}
+
+// pathCharacterAt returns the rune whose cell along the path holds the point, given in
+// the path's coordinates, or the rune whose center is closest when none does.
+func (t *Text) pathCharacterAt(x, y float32) (index int, side CharacterSide, inside bool) {
+	index, side = -1, CSUnknown
+	closest := float32(math.MaxFloat32)
+	for i, g := range t.pathGlyphs {
+		if g.advance == 0 {
+			continue
+		}
+		// into the rune's frame: x along the path, y above it
+		dx, dy := x-g.center.X, y-g.center.Y
+		lx, ly := dx*g.cos+dy*g.sin, -dx*g.sin+dy*g.cos
+		s := CSLeft
+		if lx > 0 {
+			s = CSRight
+		}
+		if lx >= -g.advance/2 && lx <= g.advance/2 && ly >= t.pathBottom && ly <= t.pathTop {
+			return i, s, true
+		}
+		if d := dx*dx + dy*dy; d < closest {
+			closest, index, side = d, i, s
+		}
+	}
+	return index, side, false
+}
@@ -0,0 +1,98 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package v45
+
+import (
+	"fmt"
+	"github.com/4ydx/gltext"
+)
+
+// Registry owns the fonts of an application, grouped by family, weight, style
+// and pixel size.  Requests are resolved to the closest font; missing sizes
+// and styles are baked on demand from registered font sources.
+//
+// A Registry is not designed to be accessed concurrently.
+type Registry struct {
+	// Options are used for every font created by the registry.
+	Options FontOptions
+
+	keys    []gltext.FontKey
+	fonts   []*Font
+	sources []gltext.FontSource
+
+	windowWidth  float32
+	windowHeight float32
+}
+
+// NewRegistry returns an empty registry creating its fonts with the given options.
+func NewRegistry(opts FontOptions) *Registry {
+	return &Registry{Options: opts}
+}
+
+// AddFont creates a font from a baked config and registers it under key.  The
+// registry releases the font along with its own resources.
+func (r *Registry) AddFont(key gltext.FontKey, config *gltext.FontConfig) (*Font, error) {
+	f, err := NewFontWithOptions(config, r.Options)
+	if err != nil {
+		f.Release()
+		return nil, err
+	}
+	if r.windowWidth > 0 || r.windowHeight > 0 {
+		f.ResizeWindow(r.windowWidth, r.windowHeight)
+	}
+	r.keys = append(r.keys, key)
+	r.fonts = append(r.fonts, f)
+	return f, nil
+}
+
+// AddSource registers a font file from which fonts of its family are baked
+// whenever a requested size or style has not been registered yet.
+func (r *Registry) AddSource(source gltext.FontSource) {
+	r.sources = append(r.sources, source)
+}
+
+// Font returns the font of the family matching the requested weight, style and
+// size.  A registered font of exactly that kind is used first, its family name
+// compared ignoring case as in every other lookup.  Otherwise the closest source
+// of the family is baked at the requested size, synthesizing bold and italic
+// faces when necessary, and kept for later requests.  Without sources, the
+// closest registered font of the family is returned.
+func (r *Registry) Font(family string, weight gltext.FontWeight, style gltext.FontStyle, size int) (*Font, error) {
+	want := gltext.FontKey{Family: family, Weight: weight, Style: style, Size: size}
+	for i, key := range r.keys {
+		if key.Matches(want) {
+			return r.fonts[i], nil
+		}
+	}
+	if i := gltext.ClosestSource(want, r.sources); i >= 0 {
+		config, err := r.sources[i].Bake(want)
+		if err != nil {
+			return nil, err
+		}
+		return r.AddFont(want, config)
+	}
+	if i := gltext.ClosestFont(want, r.keys); i >= 0 {
+		return r.fonts[i], nil
+	}
+	return nil, fmt.Errorf("No font of the family %q has been registered.", family)
+}
+
+// ResizeWindow updates the window size of every font, including fonts that
+// are created later on.
+func (r *Registry) ResizeWindow(width float32, height float32) {
+	r.windowWidth, r.windowHeight = width, height
+	for _, f := range r.fonts {
+		f.ResizeWindow(width, height)
+	}
+}
+
+// Release releases the GL resources of every font owned by the registry and
+// empties it.  Sources stay registered so fonts can be baked again.
+func (r *Registry) Release() {
+	for _, f := range r.fonts {
+		f.Release()
+	}
+	r.keys, r.fonts = nil, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package v45
+
+import (
+	"fmt"
+	"github.com/4ydx/gltext"
+	"github.com/go-gl/mathgl/mgl32"
+	"math"
+)
+
+// Run is a span of text sharing a single style.
+type Run struct {
+	Text  string
+	Color mgl32.Vec4 // rgba, an alpha of 0 makes the run invisible
+
+	// Font draws the run.  Nil uses the font of the Text.  Runs of different
+	// fonts share the baseline of the line.
+	Font *Font
+
+	// Scale multiplies the size of the run's glyphs.  0 keeps them as baked.
+	Scale float32
+
+	// Underline draws a line below the run's glyphs in the run's color.
+	Underline bool
+
+	// Effect animates the run's glyphs, see Text.EffectParams.
+	Effect Effect
+}
+
+// SetRuns lays out the runs one after another on a shared baseline.  String holds
+// the text of all runs so that RuneCount, CharSpacing and ClickedCharacter work on
+// rich text just as they do on plain text.  Glyphs are still drawn with one call per
+// font texture, whatever the number of runs.
+//
+// Colors come from the runs.  SetColor is ignored until SetString is used again.
+func (t *Text) SetRuns(runs ...Run) {
+	t.rich = true
+	t.stopTypewriter()
+	t.setRuns(runs)
+}
+
+// SetMarkup formats the string like SetString and sets the runs described by its
+// markup, see gltext.ParseMarkup.  Unstyled text uses the color set by SetColor.
+// Values inserted by the format verbs should be escaped with gltext.EscapeMarkup.
+//
+// Malformed markup is shown as plain text, tags included, and the error locating
+// the problem is returned.
+func (t *Text) SetMarkup(fs string, argv ...interface{}) error {
+	s := fmt.Sprintf(fs, argv...)
+	runs, err := t.markupRuns(s)
+	if err != nil {
+		t.SetString("%s", s)
+		return err
+	}
+	t.SetRuns(runs...)
+	return nil
+}
+
+// markupRuns converts the spans of the markup into runs.
+func (t *Text) markupRuns(s string) ([]Run, error) {
+	base := gltext.MarkupStyle{Color: [4]float32{t.color[0], t.color[1], t.color[2], 1}, Scale: 1}
+	spans, err := gltext.ParseMarkup(s, base)
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]Run, len(spans))
+	for i, span := range spans {
+		runs[i] = Run{
+			Text:      span.Text,
+			Color:     mgl32.Vec4(span.Style.Color),
+			Font:      t.styleFont(span.Style.Bold, span.Style.Italic),
+			Scale:     span.Style.Scale,
+			Underline: span.Style.Underline,
+			Effect:    effectNames[span.Style.Effect],
+		}
+	}
+	return runs, nil
+}
+
+// styleFont returns the font registered for the style, if any.
+func (t *Text) styleFont(bold, italic bool) *Font {
+	switch {
+	case bold && italic && t.BoldItalicFont != nil:
+		return t.BoldItalicFont
+	case bold && italic && t.BoldFont == nil:
+		return t.ItalicFont
+	case bold:
+		return t.BoldFont
+	case italic:
+		return t.ItalicFont
+	}
+	return nil
+}
+
+// truncateRuns keeps the first count runes of the runs.
+func truncateRuns(runs []Run, count int) []Run {
+	kept := make([]Run, 0, len(runs))
+	for _, run := range runs {
+		if count <= 0 {
+			break
+		}
+		n := 0
+		for i := range run.Text {
+			if n == count {
+				run.Text = run.Text[:i]
+				break
+			}
+			n++
+		}
+		count -= n
+		kept = append(kept, run)
+	}
+	return kept
+}
+
+// underline returns the lower edge and thickness of the underline of a run drawn
+// with the font at the given scale.  Both follow the size of the font.
+func underline(font *Font, scale float32) (y, thickness float32) {
+	em := float32(font.Config.Baseline)
+	if em == 0 {
+		em = float32(font.maxGlyphHeight)
+	}
+	thickness = float32(math.Max(1, math.Round(float64(em)/12))) * scale
+	gap := float32(math.Max(1, math.Round(float64(em)/10))) * scale
+	return -gap - thickness, thickness
+}
@@ -0,0 +1,205 @@
+// Copyright 2012 The go-gl Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package v45
+
+import (
+	"github.com/go-gl/gl/v4.5-core/gl"
+)
+
+// DefaultPauses are the extra seconds a Typewriter waits after punctuation.
+var DefaultPauses = map[rune]float32{
+	'.': 0.3, '!': 0.3, '?': 0.3, '…': 0.4,
+	',': 0.15, ';': 0.15, ':': 0.15,
+	'。': 0.3, '！': 0.3, '？': 0.3, '、': 0.15,
+}
+
+// Typewriter reveals the runes of a Text one after another by driving its RuneCount.
+// It is advanced by Text.Update.
+type Typewriter struct {
+	CharsPerSecond float32
+
+	// Pauses holds the seconds waited after a rune before revealing the next one.
+	Pauses map[rune]float32
+
+	// FadeDuration is the number of seconds each rune takes to fade in.  0 shows
+	// runes at once.
+	FadeDuration float32
+
+	// OnComplete is called with the text once every rune is fully revealed.
+	OnComplete func(t *Text)
+
+	text     *Text
+	runes    []rune
+	elapsed  float32
+	nextAt   float32   // time at which the next rune is revealed
+	starts   []float32 // time at which each revealed rune appeared
+	fading   int       // first rune that may still be fading in
+	complete bool
+	done     chan struct{}
+
+	// the quads of each rune and the vertex alphas found in the vbo
+	runeQuads [][]int32
+	alphas    []float32
+}
+
+// Typewrite hides the text and reveals it at the given rate, starting with the
+// next call to Update.  Setting a new string stops the typewriter and closes its Done
+// channel without calling OnComplete.  Changes to the layout alone, such as a gradient
+// or a path, keep the reveal going.
+func (t *Text) Typewrite(charsPerSecond float32) *Typewriter {
+	t.stopTypewriter()
+	w := &Typewriter{
+		CharsPerSecond: charsPerSecond,
+		Pauses:         DefaultPauses,
+		text:           t,
+		runes:          []rune(t.String),
+		done:           make(chan struct{}),
+	}
+	w.capture()
+	t.RuneCount = 0
+	t.typewriter = w
+	return w
+}
+
+// stopTypewriter stops the running typewriter, if any, restoring the vertex alphas of
+// runes that are still fading in.
+func (t *Text) stopTypewriter() {
+	w := t.typewriter
+	if w == nil {
+		return
+	}
+	for i := w.fading; i < len(w.runes); i++ {
+		w.setAlpha(i, 1)
+	}
+	t.typewriter = nil
+	w.complete = true
+	close(w.done)
+}
+
+// capture finds the quads of each rune and the vertex alphas in the vbo.
+func (w *Typewriter) capture() {
+	t := w.text
+	w.runeQuads = make([][]int32, len(w.runes))
+	for _, b := range t.batches {
+		for i, quad := range b.quads {
+			w.runeQuads[b.runes[i]] = append(w.runeQuads[b.runes[i]], quad)
+		}
+	}
+	w.alphas = make([]float32, len(t.vboData)/vertexStride)
+	for i := range w.alphas {
+		w.alphas[i] = t.vboData[i*vertexStride+7]
+	}
+}
+
+// relayout picks up the vertices of a new layout of the same runes, hiding and fading
+// them as before.
+func (w *Typewriter) relayout() {
+	w.capture()
+	w.fading = 0
+	w.advance(0)
+	w.upload()
+}
+
+// Done returns a channel that is closed once every rune is fully revealed.
+func (w *Typewriter) Done() <-chan struct{} {
+	return w.done
+}
+
+// Complete reports whether every rune is fully revealed.
+func (w *Typewriter) Complete() bool {
+	return w.complete
+}
+
+// Skip reveals the remaining runes at once.
+func (w *Typewriter) Skip() {
+	if w.complete {
+		return
+	}
+	w.text.RuneCount = len(w.runes)
+	for i := w.fading; i < len(w.runes); i++ {
+		w.setAlpha(i, 1)
+	}
+	w.upload()
+	w.finish()
+}
+
+// update advances the typewriter by dt seconds and uploads faded vertices.
+func (w *Typewriter) update(dt float32) {
+	if w.advance(dt) {
+		w.upload()
+	}
+	if !w.complete && w.revealed() {
+		w.finish()
+	}
+}
+
+// advance reveals runes and fades them in, returning true when vertex colors changed.
+func (w *Typewriter) advance(dt float32) bool {
+	if w.complete {
+		return false
+	}
+	w.elapsed += dt
+	interval := float32(0)
+	if w.CharsPerSecond > 0 {
+		interval = 1 / w.CharsPerSecond
+	}
+	for len(w.starts) < len(w.runes) && (w.elapsed >= w.nextAt || w.CharsPerSecond <= 0) {
+		w.starts = append(w.starts, w.nextAt)
+		w.nextAt += interval + w.Pauses[w.runes[len(w.starts)-1]]
+	}
+	w.text.RuneCount = len(w.starts)
+
+	changed := false
+	for i := w.fading; i < len(w.starts); i++ {
+		alpha := float32(1)
+		if w.FadeDuration > 0 && w.elapsed-w.starts[i] < w.FadeDuration {
+			alpha = (w.elapsed - w.starts[i]) / w.FadeDuration
+		}
+		changed = w.setAlpha(i, alpha) || changed
+		if alpha == 1 && i == w.fading {
+			w.fading++
+		}
+	}
+	return changed
+}
+
+// revealed reports whether every rune has appeared and finished fading in.
+func (w *Typewriter) revealed() bool {
+	return w.fading == len(w.runes)
+}
+
+func (w *Typewriter) finish() {
+	w.complete = true
+	w.fading = len(w.runes)
+	if w.text.typewriter == w {
+		w.text.typewriter = nil
+	}
+	close(w.done)
+	if w.OnComplete != nil {
+		w.OnComplete(w.text)
+	}
+}
+
+// setAlpha multiplies the vertex alphas of the rune's quads with alpha, returning
+// true when vertices may have changed.
+func (w *Typewriter) setAlpha(index int, alpha float32) bool {
+	for _, quad := range w.runeQuads[index] {
+		for v := quad * 4; v < quad*4+4; v++ {
+			w.text.vboData[int(v)*vertexStride+7] = w.alphas[v] * alpha
+		}
+	}
+	return len(w.runeQuads[index]) > 0 && w.FadeDuration > 0
+}
+
+// upload copies the vertices to the vbo.
+func (w *Typewriter) upload() {
+	t := w.text
+	if t.vbo == 0 || len(t.vboData) == 0 {
+		return
+	}
+	gl.BindBuffer(gl.ARRAY_BUFFER, t.vbo)
+	gl.BufferSubData(gl.ARRAY_BUFFER, 0, len(t.vboData)*4, gl.Ptr(t.vboData))
+	gl.BindBuffer(gl.ARRAY_BUFFER, 0)
+}